@@ -2,10 +2,14 @@ module privcrawler
 
 go 1.24.0
 
-require github.com/playwright-community/playwright-go v0.5200.0
+require (
+	github.com/playwright-community/playwright-go v0.5200.0
+	golang.org/x/net v0.47.0
+)
 
 require (
 	github.com/deckarep/golang-set/v2 v2.7.0 // indirect
 	github.com/go-jose/go-jose/v3 v3.0.4 // indirect
 	github.com/go-stack/stack v1.8.1 // indirect
+	golang.org/x/text v0.31.0 // indirect
 )
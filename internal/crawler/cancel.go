@@ -0,0 +1,69 @@
+package crawler
+
+import (
+	"context"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- Functions ---- //
+
+// Function: Sleep Context
+// Operation: Waits for duration milliseconds, returning early when ctx is done.
+// Used instead of page.WaitForTimeout, which cannot be interrupted.
+// Return: ctx.Err() if the wait was cut short, otherwise nil
+func sleepContext(ctx context.Context, duration int) error {
+	if duration <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(time.Duration(duration) * time.Millisecond)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Function: Close On Cancel
+// Operation: Closes the page when ctx is done so a blocked Goto or evaluation returns.
+// The browser context stays open so the cookies collected so far can still be read.
+// Return: A function that stops the watcher
+func closeOnCancel(ctx context.Context, page playwright.Page) func() {
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			page.Close()
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+	}
+}
+
+// Function: Apply Deadline
+// Operation: Shortens the page timeouts so no single Playwright call outlives
+// the deadline of ctx.
+// Return: None
+func applyDeadline(ctx context.Context, page playwright.Page) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return
+	}
+
+	remaining := float64(time.Until(deadline).Milliseconds())
+	if remaining < 1 {
+		remaining = 1
+	}
+
+	page.SetDefaultTimeout(remaining)
+	page.SetDefaultNavigationTimeout(remaining)
+}
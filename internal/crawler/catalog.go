@@ -0,0 +1,138 @@
+package crawler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Cookie Purpose: Represents what a known cookie is used for.
+type CookiePurpose string
+
+const (
+	PurposeEssential   CookiePurpose = "essential"
+	PurposeAnalytics   CookiePurpose = "analytics"
+	PurposeMarketing   CookiePurpose = "marketing"
+	PurposePreferences CookiePurpose = "preferences"
+	PurposeUnknown     CookiePurpose = "unknown" // Not in the catalog.
+)
+
+// Catalog Entry: Represents one cookie name pattern in the catalog file.
+// Pattern is a regular expression that must match the whole cookie name.
+type CatalogEntry struct {
+	Pattern  string        `json:"pattern"`
+	Vendor   string        `json:"vendor"`
+	Purpose  CookiePurpose `json:"purpose"`
+	Lifetime string        `json:"lifetime"`
+
+	pattern *regexp.Regexp
+}
+
+// Cookie Catalog: Represents the structure of the catalog file.
+type CookieCatalog struct {
+	Version string         `json:"version"`
+	Cookies []CatalogEntry `json:"cookies"`
+}
+
+// ---- Global Definitions ---- //
+
+// Cookie Catalog File: Location of the known-cookie catalog.
+const COOKIECATALOGFILE string = "internal/config/cookies.json"
+
+// Order in which purposes are reported.
+var cookiePurposes = []CookiePurpose{PurposeEssential, PurposeAnalytics, PurposeMarketing,
+	PurposePreferences, PurposeUnknown}
+
+// ---- Functions ---- //
+
+// Function: Read Cookie Catalog
+// Operation: Reads the cookie catalog from the JSON file at the given path and
+// compiles its patterns.
+// Return: *CookieCatalog, Error
+func ReadCookieCatalog(path string) (*CookieCatalog, error) {
+
+	// Read catalog file into data variable.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cookie catalog: %v", err)
+	}
+
+	// Parse JSON into data structure.
+	var catalog CookieCatalog
+	err = json.Unmarshal(data, &catalog)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cookie catalog: %v", err)
+	}
+
+	for i := range catalog.Cookies {
+		entry := &catalog.Cookies[i]
+
+		switch entry.Purpose {
+		case PurposeEssential, PurposeAnalytics, PurposeMarketing, PurposePreferences:
+		default:
+			return nil, fmt.Errorf("cookie catalog entry %s has unsupported purpose %s", entry.Pattern, entry.Purpose)
+		}
+
+		entry.pattern, err = regexp.Compile("^(?:" + entry.Pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("cookie catalog entry %s: %v", entry.Pattern, err)
+		}
+	}
+
+	return &catalog, nil
+}
+
+// Function: Lookup
+// Operation: Finds the first catalog entry whose pattern matches the cookie name.
+// Return: *CatalogEntry, nil if the cookie is not in the catalog
+func (catalog *CookieCatalog) Lookup(name string) *CatalogEntry {
+	if catalog == nil {
+		return nil
+	}
+
+	for i := range catalog.Cookies {
+		if catalog.Cookies[i].pattern.MatchString(name) {
+			return &catalog.Cookies[i]
+		}
+	}
+	return nil
+}
+
+// Function: Label Cookie
+// Operation: Sets the vendor, purpose and typical lifetime of the cookie from the catalog.
+// Return: Cookie
+func (catalog *CookieCatalog) labelCookie(cookie Cookie) Cookie {
+	entry := catalog.Lookup(cookie.Name)
+	if entry == nil {
+		cookie.Purpose = PurposeUnknown
+		return cookie
+	}
+
+	cookie.Vendor = entry.Vendor
+	cookie.Purpose = entry.Purpose
+	cookie.TypicalLifetime = entry.Lifetime
+	return cookie
+}
+
+// Function: Version Of
+// Operation: Returns the version of the catalog for reports.
+// Return: String
+func (catalog *CookieCatalog) versionOf() string {
+	if catalog == nil {
+		return "none"
+	}
+	return catalog.Version
+}
+
+// Function: Cookie Label
+// Operation: Returns the vendor and purpose of the cookie for reports.
+// Return: String
+func cookieLabel(cookie Cookie) string {
+	if cookie.Vendor == "" {
+		return string(PurposeUnknown)
+	}
+	return fmt.Sprintf("%s (%s)", cookie.Vendor, cookie.Purpose)
+}
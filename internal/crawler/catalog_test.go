@@ -0,0 +1,90 @@
+package crawler
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadCookieCatalog(t *testing.T) {
+	catalog, err := ReadCookieCatalog(filepath.Join("..", "config", "cookies.json"))
+	if err != nil {
+		t.Fatalf("shipped catalog: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		vendor  string
+		purpose CookiePurpose
+	}{
+		{"_ga", "Google Analytics", PurposeAnalytics},
+		{"_ga_ABC123XYZ", "Google Analytics", PurposeAnalytics},
+		{"_fbp", "Meta", PurposeMarketing},
+		{"IDE", "Google DoubleClick", PurposeMarketing},
+		{"__gads", "Google AdSense", PurposeMarketing},
+
+		// Patterns match the whole name.
+		{"_gax", "", PurposeUnknown},
+		{"xIDE", "", PurposeUnknown},
+		{"ide", "", PurposeUnknown},
+		{"session_token_42", "", PurposeUnknown},
+	}
+
+	for _, test := range tests {
+		cookie := catalog.labelCookie(Cookie{Name: test.name})
+		if cookie.Vendor != test.vendor || cookie.Purpose != test.purpose {
+			t.Errorf("%s labelled %q (%s), want %q (%s)", test.name, cookie.Vendor, cookie.Purpose, test.vendor, test.purpose)
+		}
+		if entry := catalog.Lookup(test.name); (entry == nil) != (test.purpose == PurposeUnknown) {
+			t.Errorf("Lookup(%s) = %+v", test.name, entry)
+		}
+	}
+
+	// A missing catalog labels every cookie as unknown.
+	var none *CookieCatalog
+	if cookie := none.labelCookie(Cookie{Name: "_ga"}); cookie.Purpose != PurposeUnknown {
+		t.Errorf("nil catalog labelled _ga %s", cookie.Purpose)
+	}
+}
+
+func TestCookieCatalogErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// The first matching entry wins.
+	catalog, err := ReadCookieCatalog(write("order.json", `{"cookies": [
+		{"pattern": "_ga", "vendor": "Exact", "purpose": "analytics"},
+		{"pattern": "_ga.*", "vendor": "Prefix", "purpose": "marketing"}]}`))
+	if err != nil {
+		t.Fatalf("ReadCookieCatalog: %v", err)
+	}
+	if vendor := catalog.Lookup("_ga").Vendor; vendor != "Exact" {
+		t.Errorf("_ga matched %s, want the first entry", vendor)
+	}
+	if vendor := catalog.Lookup("_gat").Vendor; vendor != "Prefix" {
+		t.Errorf("_gat matched %s, want the second entry", vendor)
+	}
+
+	tests := []struct {
+		path string
+		err  string
+	}{
+		{filepath.Join(dir, "missing.json"), "error reading cookie catalog"},
+		{write("broken.json", `{"cookies": [`), "error parsing cookie catalog"},
+		{write("purpose.json", `{"cookies": [{"pattern": "_ga", "purpose": "tracking"}]}`), "unsupported purpose tracking"},
+		{write("unknown.json", `{"cookies": [{"pattern": "_ga", "purpose": "unknown"}]}`), "unsupported purpose unknown"},
+		{write("regex.json", `{"cookies": [{"pattern": "_ga(", "purpose": "analytics"}]}`), "cookie catalog entry _ga("},
+	}
+	for _, test := range tests {
+		if _, err := ReadCookieCatalog(test.path); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("ReadCookieCatalog(%s) error %v, want one containing %q", filepath.Base(test.path), err, test.err)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package crawler
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// CNAME Resolver: Resolves the canonical names of hosts, caching every answer.
+// It is safe to share between crawls.
+type CNAMEResolver struct {
+	address string // DNS server host:port.
+	timeout time.Duration
+
+	// Name to CNAME target, the name itself when it has none.
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+// Cloaked Host: Represents a first-party looking host that is an alias of a tracker.
+type CloakedHost struct {
+	Host      string
+	Canonical string   // End of the CNAME chain.
+	Chain     []string // Every name after the host, ending with Canonical.
+
+	// First name in the chain on the tracker lists, and its category.
+	TrackerHost string
+	Tracker     TrackerCategory
+}
+
+// ---- Global Definitions ---- //
+
+// Time allowed for one CNAME lookup, in milliseconds.
+const CNAME_LOOKUP_TIMEOUT int = 2000 // 2s
+
+// Longest CNAME chain followed from one host.
+const MAX_CNAME_CHAIN int = 10
+
+// System resolver configuration, and the server used when it lists none.
+const RESOLV_CONF string = "/etc/resolv.conf"
+const DEFAULT_NAMESERVER string = "127.0.0.1:53"
+
+// Largest DNS response accepted over UDP, longer answers are retried over TCP.
+const MAX_DNS_UDP_SIZE int = 1232
+
+// ---- Functions ---- //
+
+// Function: New CNAME Resolver
+// Operation: Creates a resolver that asks the DNS server at address (host:port),
+// or the first nameserver of the system resolver when address is empty.
+// Return: *CNAMEResolver
+func NewCNAMEResolver(address string) *CNAMEResolver {
+	if address == "" {
+		address = systemNameserver(RESOLV_CONF)
+	}
+
+	return &CNAMEResolver{
+		address: address,
+		timeout: time.Duration(CNAME_LOOKUP_TIMEOUT) * time.Millisecond,
+		cache:   make(map[string]string),
+	}
+}
+
+// Function: System Nameserver
+// Operation: Reads the first nameserver from a resolv.conf file.
+// Return: String host:port, DEFAULT_NAMESERVER if the file lists none
+func systemNameserver(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return DEFAULT_NAMESERVER
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "nameserver" {
+			continue
+		}
+		// Drop the zone of link-local IPv6 servers, as in fe80::1%eth0.
+		server, _, _ := strings.Cut(fields[1], "%")
+		if net.ParseIP(server) != nil {
+			return net.JoinHostPort(server, "53")
+		}
+	}
+
+	return DEFAULT_NAMESERVER
+}
+
+// Function: Chain
+// Operation: Follows the CNAME chain of the host one name at a time, up to
+// MAX_CNAME_CHAIN names, stopping at a name without a CNAME or at a loop.
+// Return: []string of the names after the host, empty if it has no CNAME or the lookup fails
+func (r *CNAMEResolver) Chain(ctx context.Context, host string) []string {
+	var chain []string
+	visited := map[string]bool{normalizeCookieDomain(host): true}
+
+	name := host
+	for len(chain) < MAX_CNAME_CHAIN {
+		next, ok := r.lookup(ctx, name)
+		if !ok || visited[next] {
+			break
+		}
+		visited[next] = true
+		chain = append(chain, next)
+		name = next
+	}
+
+	return chain
+}
+
+// Function: Lookup
+// Operation: Finds the CNAME target of one name, from the cache when possible.
+// Return: String without trailing dot, bool false if the name has no CNAME
+func (r *CNAMEResolver) lookup(ctx context.Context, host string) (string, bool) {
+	host = normalizeCookieDomain(host)
+
+	r.mu.Lock()
+	target, ok := r.cache[host]
+	r.mu.Unlock()
+	if !ok {
+		lookupCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		name, err := r.queryCNAME(lookupCtx, host)
+		cancel()
+
+		if err != nil && ctx.Err() != nil {
+			// Do not cache lookups cut short by the crawl ending.
+			return "", false
+		}
+		target = host
+		if err == nil && name != "" {
+			target = name
+		}
+
+		r.mu.Lock()
+		r.cache[host] = target
+		r.mu.Unlock()
+	}
+
+	return target, target != host
+}
+
+// Function: Query CNAME
+// Operation: Asks the DNS server for the CNAME record of the name itself. Unlike
+// net.Resolver.LookupCNAME, which returns the end of the chain, this gives one hop.
+// A truncated UDP answer is asked again over TCP.
+// Return: String target without trailing dot, empty if the name has no CNAME; Error
+func (r *CNAMEResolver) queryCNAME(ctx context.Context, host string) (string, error) {
+	name, err := dnsmessage.NewName(host + ".")
+	if err != nil {
+		return "", err
+	}
+
+	id := uint16(rand.Uint32())
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	builder.EnableCompression()
+	if err := builder.StartQuestions(); err != nil {
+		return "", err
+	}
+	err = builder.Question(dnsmessage.Question{Name: name, Type: dnsmessage.TypeCNAME, Class: dnsmessage.ClassINET})
+	if err != nil {
+		return "", err
+	}
+	query, err := builder.Finish()
+	if err != nil {
+		return "", err
+	}
+
+	answer, err := r.exchange(ctx, "udp", query)
+	if err != nil {
+		return "", err
+	}
+	var message dnsmessage.Message
+	if err := message.Unpack(answer); err != nil {
+		return "", err
+	}
+	if message.Truncated {
+		if answer, err = r.exchange(ctx, "tcp", query); err != nil {
+			return "", err
+		}
+		if err := message.Unpack(answer); err != nil {
+			return "", err
+		}
+	}
+
+	if message.ID != id || !message.Response {
+		return "", errors.New("dns answer does not match the query")
+	}
+	switch message.RCode {
+	case dnsmessage.RCodeSuccess, dnsmessage.RCodeNameError:
+	default:
+		return "", fmt.Errorf("dns server answered %v", message.RCode)
+	}
+
+	for _, record := range message.Answers {
+		cname, ok := record.Body.(*dnsmessage.CNAMEResource)
+		if ok && strings.EqualFold(record.Header.Name.String(), name.String()) {
+			return strings.ToLower(strings.TrimSuffix(cname.CNAME.String(), ".")), nil
+		}
+	}
+
+	return "", nil
+}
+
+// Function: Exchange
+// Operation: Sends one DNS query to the server over udp or tcp and reads the
+// answer, with the 2-byte length prefix TCP uses.
+// Return: []byte, Error
+func (r *CNAMEResolver) exchange(ctx context.Context, network string, query []byte) ([]byte, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, r.address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if network == "udp" {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+		answer := make([]byte, MAX_DNS_UDP_SIZE)
+		n, err := conn.Read(answer)
+		if err != nil {
+			return nil, err
+		}
+		return answer[:n], nil
+	}
+
+	framed := binary.BigEndian.AppendUint16(nil, uint16(len(query)))
+	if _, err := conn.Write(append(framed, query...)); err != nil {
+		return nil, err
+	}
+	var length [2]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return nil, err
+	}
+	answer := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(conn, answer); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+// Function: Detect Cloaking
+// Operation: Resolves the given first-party hosts and keeps those with a name on
+// another site and on the tracker lists anywhere in their CNAME chain.
+// Return: map of host to CloakedHost
+func detectCloaking(ctx context.Context, resolver *CNAMEResolver, hosts []string, siteURL string,
+	trackers *TrackerLists) map[string]CloakedHost {
+
+	cloaked := make(map[string]CloakedHost)
+	for _, host := range hosts {
+		if ctx.Err() != nil {
+			break
+		}
+
+		host = normalizeCookieDomain(host)
+		if _, done := cloaked[host]; done || host == "" {
+			continue
+		}
+
+		chain := resolver.Chain(ctx, host)
+		for _, name := range chain {
+			if isFirstParty(name, siteURL) {
+				continue
+			}
+			if category := trackers.Classify(name); category.IsTracker() {
+				cloaked[host] = CloakedHost{
+					Host:        host,
+					Canonical:   chain[len(chain)-1],
+					Chain:       chain,
+					TrackerHost: name,
+					Tracker:     category,
+				}
+				break
+			}
+		}
+	}
+
+	return cloaked
+}
+
+// Function: Cloaked List
+// Operation: Returns the cloaked hosts in the order given.
+// Return: []CloakedHost
+func cloakedList(cloaked map[string]CloakedHost, hosts []string) []CloakedHost {
+	var list []CloakedHost
+	listed := make(map[string]bool)
+	for _, host := range hosts {
+		host = normalizeCookieDomain(host)
+		if c, ok := cloaked[host]; ok && !listed[host] {
+			listed[host] = true
+			list = append(list, c)
+		}
+	}
+	return list
+}
+
+// Function: Mark Cloaked Requests
+// Operation: Marks requests to cloaked hosts as third-party.
+// Return: None
+func markCloakedRequests(requests []NetworkRequest, cloaked map[string]CloakedHost) {
+	for i := range requests {
+		if c, ok := cloaked[requests[i].Host]; ok {
+			requests[i].IsFirstParty = false
+			requests[i].CloakedBy = c.TrackerHost
+		}
+	}
+}
+
+// Function: Mark Cloaked Cookie
+// Operation: Marks a cookie of a cloaked domain as a third-party tracker cookie.
+// Return: Cookie
+func markCloakedCookie(cookie Cookie, cloaked map[string]CloakedHost) Cookie {
+	if c, ok := cloaked[normalizeCookieDomain(cookie.Domain)]; ok {
+		cookie.IsFirstParty = false
+		cookie.Tracker = c.Tracker
+		cookie.CloakedBy = c.TrackerHost
+	}
+	return cookie
+}
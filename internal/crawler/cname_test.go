@@ -0,0 +1,135 @@
+package crawler
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Function: Serve CNAMEs
+// Operation: Starts a UDP DNS server on localhost that answers CNAME queries from
+// records, one record per name like an authoritative server, and NXDOMAIN otherwise.
+// Return: String address, stopped when the test ends
+func serveCNAMEs(t *testing.T, records map[string]string) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buffer := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+
+			var query dnsmessage.Message
+			if query.Unpack(buffer[:n]) != nil || len(query.Questions) != 1 {
+				continue
+			}
+			question := query.Questions[0]
+			answer := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.ID, Response: true, RCode: dnsmessage.RCodeNameError},
+				Questions: query.Questions,
+			}
+			target, ok := records[strings.TrimSuffix(question.Name.String(), ".")]
+			if ok && question.Type == dnsmessage.TypeCNAME {
+				answer.RCode = dnsmessage.RCodeSuccess
+				answer.Answers = []dnsmessage.Resource{{
+					Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeCNAME,
+						Class: dnsmessage.ClassINET, TTL: 60},
+					Body: &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName(target + ".")},
+				}}
+			}
+
+			packed, err := answer.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestCNAMECloaking(t *testing.T) {
+	address := serveCNAMEs(t, map[string]string{
+		"metrics.example.com": "metrics.example.net",
+		"metrics.example.net": "collect.tracker.com",
+		"collect.tracker.com": "edge.cdn-host.net",
+		"static.example.com":  "static.example.net",
+		"static.example.net":  "static.cdn-host.net",
+		"loop-a.example.com":  "loop-b.example.com",
+		"loop-b.example.com":  "loop-a.example.com",
+		"www.example.com":     "example.com",
+	})
+
+	resolver := NewCNAMEResolver(address)
+	ctx := context.Background()
+
+	chains := []struct {
+		host  string
+		chain []string
+	}{
+		{"metrics.example.com", []string{"metrics.example.net", "collect.tracker.com", "edge.cdn-host.net"}},
+		{"METRICS.example.com", []string{"metrics.example.net", "collect.tracker.com", "edge.cdn-host.net"}},
+		{"www.example.com", []string{"example.com"}},
+		{"loop-a.example.com", []string{"loop-b.example.com"}},
+		{"unknown.example.com", nil},
+	}
+	for _, test := range chains {
+		if chain := resolver.Chain(ctx, test.host); !reflect.DeepEqual(chain, test.chain) {
+			t.Errorf("Chain(%q) = %v, want %v", test.host, chain, test.chain)
+		}
+	}
+
+	trackers := &TrackerLists{domains: map[string]TrackerCategory{
+		"tracker.com":  TrackerAnalytics,
+		"cdn-host.net": TrackerCDN,
+	}}
+	hosts := []string{"metrics.example.com", ".static.example.com", "www.example.com", "unknown.example.com"}
+	cloaked := detectCloaking(ctx, resolver, hosts, "https://www.example.com/", trackers)
+
+	want := map[string]CloakedHost{
+		"metrics.example.com": {
+			Host:        "metrics.example.com",
+			Canonical:   "edge.cdn-host.net",
+			Chain:       []string{"metrics.example.net", "collect.tracker.com", "edge.cdn-host.net"},
+			TrackerHost: "collect.tracker.com",
+			Tracker:     TrackerAnalytics,
+		},
+	}
+	if !reflect.DeepEqual(cloaked, want) {
+		t.Fatalf("detectCloaking = %+v, want %+v", cloaked, want)
+	}
+
+	cookie := markCloakedCookie(Cookie{Name: "_id", Domain: ".metrics.example.com", IsFirstParty: true}, cloaked)
+	if cookie.IsFirstParty || cookie.Tracker != TrackerAnalytics || cookie.CloakedBy != "collect.tracker.com" {
+		t.Errorf("cloaked cookie = %+v", cookie)
+	}
+	cookie = markCloakedCookie(Cookie{Name: "session", Domain: "www.example.com", IsFirstParty: true}, cloaked)
+	if !cookie.IsFirstParty || cookie.Tracker != "" || cookie.CloakedBy != "" {
+		t.Errorf("first-party cookie = %+v", cookie)
+	}
+
+	requests := []NetworkRequest{
+		{URL: "https://metrics.example.com/collect", Host: "metrics.example.com", IsFirstParty: true},
+		{URL: "https://www.example.com/", Host: "www.example.com", IsFirstParty: true},
+	}
+	markCloakedRequests(requests, cloaked)
+	if requests[0].IsFirstParty || requests[0].CloakedBy != "collect.tracker.com" {
+		t.Errorf("cloaked request = %+v", requests[0])
+	}
+	if !requests[1].IsFirstParty || requests[1].CloakedBy != "" {
+		t.Errorf("first-party request = %+v", requests[1])
+	}
+}
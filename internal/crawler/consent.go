@@ -0,0 +1,324 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Consent Mode: Represents what the crawler does with a consent banner.
+type ConsentMode string
+
+const (
+	ConsentNoAction ConsentMode = "no-action"
+	ConsentAccept   ConsentMode = "accept-all"
+	ConsentReject   ConsentMode = "reject-all"
+
+	// ConsentCompare crawls the site once in each of the three modes.
+	ConsentCompare ConsentMode = "compare"
+)
+
+// Consent Rule: Represents the selectors of one consent management platform (CMP).
+type ConsentRule struct {
+	Name   string `json:"name"`
+	Detect string `json:"detect"`
+	Accept string `json:"accept"`
+	Reject string `json:"reject"`
+}
+
+// Consent Rules: Represents the structure of the consent rule file.
+type ConsentRules struct {
+	CMPs []ConsentRule `json:"cmps"`
+}
+
+// Consent Result: Represents what happened with the consent banner on the landing page.
+type ConsentResult struct {
+	Mode    ConsentMode
+	CMP     string // Name of the detected CMP, empty if no banner was found.
+	Clicked bool
+	Error   string
+}
+
+// Consent Comparison: Represents one site crawled in every consent mode.
+type ConsentComparison struct {
+	URL     string
+	Browser string
+	Metrics map[ConsentMode]PrivacyMetric
+	Cookies map[ConsentMode]*CookiesList
+}
+
+// ---- Global Definitions ---- //
+
+// Consent File: Location of the pre-configured CMP selectors.
+const CONSENTFILE string = "internal/config/consent.json"
+
+// Consent Report File: Where consent comparisons are written.
+const CONSENTREPORTFILE string = "CONSENT.txt"
+
+// How long to look for a banner after the landing page loads, and how often.
+const CONSENT_DETECT_TIMEOUT int = 5000 // 5s
+const CONSENT_POLL_INTERVAL int = 500   // 0.5s
+
+// Consent modes in the order they are crawled and reported.
+var consentModes = []ConsentMode{ConsentNoAction, ConsentAccept, ConsentReject}
+
+// ---- Functions ---- //
+
+// Function: Read Consent Rules
+// Operation: Reads the CMP selectors from the JSON file at the given path.
+// Return: *ConsentRules, Error
+func ReadConsentRules(path string) (*ConsentRules, error) {
+
+	// Read consent file into data variable.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading consent file: %v", err)
+	}
+
+	// Parse JSON into data structure.
+	var rules ConsentRules
+	err = json.Unmarshal(data, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing consent file: %v", err)
+	}
+
+	for i, rule := range rules.CMPs {
+		if rule.Name == "" || rule.Detect == "" {
+			return nil, fmt.Errorf("consent rule %d needs a name and a detect selector", i+1)
+		}
+	}
+
+	return &rules, nil
+}
+
+// Function: Validate Consent Mode
+// Operation: Checks that the mode is one of the consent modes, or compare.
+// Return: Error
+func ValidateConsentMode(mode ConsentMode) error {
+	switch mode {
+	case "", ConsentNoAction, ConsentAccept, ConsentReject, ConsentCompare:
+		return nil
+	}
+	return fmt.Errorf("consent mode %s is not supported", mode)
+}
+
+// Function: Handle Consent
+// Operation: Looks for a known consent banner in every frame of the page and
+// clicks accept or reject for the mode. No-action only records the banner.
+// Return: ConsentResult
+func handleConsent(ctx context.Context, page playwright.Page, rules *ConsentRules, mode ConsentMode, verbose bool) ConsentResult {
+	result := ConsentResult{Mode: mode}
+	if rules == nil || len(rules.CMPs) == 0 {
+		return result
+	}
+
+	// Banners are often injected a moment after load, so poll for a while.
+	deadline := time.Now().Add(time.Duration(CONSENT_DETECT_TIMEOUT) * time.Millisecond)
+	for {
+		frame, rule := findConsentBanner(page, rules)
+		if rule != nil {
+			result.CMP = rule.Name
+			if verbose {
+				fmt.Printf("Consent banner found: %s\n", rule.Name)
+			}
+
+			selector := ""
+			switch mode {
+			case ConsentAccept:
+				selector = rule.Accept
+			case ConsentReject:
+				selector = rule.Reject
+			}
+			if selector == "" {
+				return result
+			}
+
+			err := frame.Locator(selector).First().Click(playwright.LocatorClickOptions{
+				Timeout: playwright.Float(float64(CONSENT_DETECT_TIMEOUT)),
+			})
+			if err != nil {
+				result.Error, _, _ = strings.Cut(err.Error(), "\n")
+				return result
+			}
+			result.Clicked = true
+			return result
+		}
+
+		if time.Now().After(deadline) || sleepContext(ctx, CONSENT_POLL_INTERVAL) != nil {
+			return result
+		}
+	}
+}
+
+// Function: Find Consent Banner
+// Operation: Checks every frame for a visible banner of a known CMP.
+// Return: Frame and rule of the banner, nil if none is visible
+func findConsentBanner(page playwright.Page, rules *ConsentRules) (playwright.Frame, *ConsentRule) {
+	for _, frame := range page.Frames() {
+		for i := range rules.CMPs {
+			rule := &rules.CMPs[i]
+
+			visible, err := frame.Locator(rule.Detect).First().IsVisible()
+			if err == nil && visible {
+				return frame, rule
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Function: Consent Banner
+// Operation: Returns the name of the detected CMP for reports.
+// Return: String
+func consentBanner(result ConsentResult) string {
+	if result.CMP == "" {
+		return "none"
+	}
+	return result.CMP
+}
+
+// Function: Compare Consent Modes
+// Operation: Crawls the site once per consent mode, each in a fresh context.
+// Return: *ConsentComparison
+func CompareConsentModes(ctx context.Context, options CrawlOptions) *ConsentComparison {
+	comparison := &ConsentComparison{
+		URL:     options.URL,
+		Browser: options.Browser,
+		Metrics: make(map[ConsentMode]PrivacyMetric),
+		Cookies: make(map[ConsentMode]*CookiesList),
+	}
+
+	for _, mode := range consentModes {
+		if ctx.Err() != nil {
+			break
+		}
+
+		modeOptions := options
+		modeOptions.Consent = mode
+
+		privacyMetric := PrivacyMetric{}
+		comparison.Cookies[mode] = FetchCookiesWithOptions(ctx, modeOptions, &privacyMetric)
+		comparison.Metrics[mode] = privacyMetric
+	}
+
+	return comparison
+}
+
+// Function: Create Consent Report
+// Operation: Summarizes each consent mode and the cookies that differ between them.
+// Return: A string which contains the report
+func CreateConsentReport(comparison *ConsentComparison) string {
+	var report strings.Builder
+
+	report.WriteString(fmt.Sprintf("#----- Consent Modes for %s ------#\n", comparison.URL))
+
+	for _, mode := range consentModes {
+		metric, ok := comparison.Metrics[mode]
+		if !ok {
+			report.WriteString(fmt.Sprintf("%s: not crawled\n", mode))
+			continue
+		}
+
+		report.WriteString(fmt.Sprintf("%s: outcome %s, banner %s, clicked %t\n",
+			mode, metric.Outcome.Category, consentBanner(metric.Consent), metric.Consent.Clicked))
+		report.WriteString(fmt.Sprintf("\tCookies: %d (first-party %d, third-party %d, persistent %d)\n",
+			metric.TotalCookies, metric.TotalFirstParty, metric.TotalThirdParty, metric.TotalPersistentCookies))
+	}
+
+	// Differences between each pair of modes.
+	pairs := [][2]ConsentMode{
+		{ConsentNoAction, ConsentAccept},
+		{ConsentNoAction, ConsentReject},
+		{ConsentReject, ConsentAccept},
+	}
+	for _, pair := range pairs {
+		from, okFrom := comparison.Metrics[pair[0]]
+		to, okTo := comparison.Metrics[pair[1]]
+		if !okFrom || !okTo {
+			continue
+		}
+
+		report.WriteString(fmt.Sprintf("%s -> %s: %+d cookies, %+d third-party\n", pair[0], pair[1],
+			to.TotalCookies-from.TotalCookies, to.TotalThirdParty-from.TotalThirdParty))
+
+		added := cookieDifference(comparison.Cookies[pair[1]], comparison.Cookies[pair[0]])
+		for _, name := range added {
+			report.WriteString(fmt.Sprintf("\t+ %s\n", name))
+		}
+		removed := cookieDifference(comparison.Cookies[pair[0]], comparison.Cookies[pair[1]])
+		for _, name := range removed {
+			report.WriteString(fmt.Sprintf("\t- %s\n", name))
+		}
+	}
+
+	report.WriteString("#--------------------------------------------#\n")
+
+	return report.String()
+}
+
+// Function: Cookie Difference
+// Operation: Lists the cookies in list a that are not in list b, as domain/name.
+// Return: []string, sorted
+func cookieDifference(a, b *CookiesList) []string {
+	if a == nil {
+		return nil
+	}
+
+	inB := make(map[string]bool)
+	if b != nil {
+		for _, cookies := range b.List {
+			for _, cookie := range cookies {
+				inB[cookieKey(cookie)] = true
+			}
+		}
+	}
+
+	var names []string
+	for _, cookies := range a.List {
+		for _, cookie := range cookies {
+			if !inB[cookieKey(cookie)] {
+				names = append(names, cookie.Domain+"/"+cookie.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Function: Append Consent To File
+// Operation: Appends the consent comparison report to CONSENT.txt with timestamp and metadata
+// Return: Error
+func AppendConsentToFile(report, url, browser string) error {
+	// Open file in append mode, create if it doesn't exist
+	file, err := os.OpenFile(CONSENTREPORTFILE, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", CONSENTREPORTFILE, err)
+	}
+	defer file.Close()
+
+	// Create a formatted entry with timestamp and metadata
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	entry := "\n=== Consent Comparison Report ===\n"
+	entry += fmt.Sprintf("Timestamp: %s\n", timestamp)
+	entry += fmt.Sprintf("URL: %s\n", url)
+	entry += fmt.Sprintf("Browser: %s\n", browser)
+	entry += fmt.Sprintf("Report:\n%s\n", report)
+	entry += "=== End Report ===\n\n"
+
+	// Write the entry to the file
+	_, err = file.WriteString(entry)
+	if err != nil {
+		return fmt.Errorf("failed to write to %s: %v", CONSENTREPORTFILE, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package crawler
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Cookie Sync: Represents a cookie value set by one domain that was sent to another.
+type CookieSync struct {
+	Cookie            string
+	SourceDomain      string // Domain of the cookie.
+	DestinationDomain string // Host the value was sent to.
+	Parameter         string // Query parameter, "path", "header:<name>" or "body[:<field>]".
+	RequestURL        string // First request that carried the value.
+}
+
+// ---- Global Definitions ---- //
+
+// Cookie values, or parts of them, shorter than this are too common to be identifiers.
+const MIN_SYNC_VALUE_LENGTH int = 8
+
+// ---- Functions ---- //
+
+// Function: Detect Cookie Syncs
+// Operation: Looks for the identifiers in cookie values in the URL, headers and body
+// of requests sent to a different site than the one that set the cookie.
+// Each cookie, destination and parameter is reported once.
+// Return: []CookieSync
+func detectCookieSyncs(cookies []Cookie, requests []NetworkRequest) []CookieSync {
+	var syncs []CookieSync
+	reported := make(map[string]bool)
+
+	for _, cookie := range cookies {
+		tokens := syncTokens(cookie.Value)
+		if len(tokens) == 0 {
+			continue
+		}
+		source := normalizeCookieDomain(cookie.Domain)
+
+		for _, request := range requests {
+			if request.Host == "" || isFirstParty(request.Host, "https://"+source) {
+				continue
+			}
+
+			for _, parameter := range findTokens(request, tokens) {
+				key := cookie.Name + "|" + source + "|" + request.Host + "|" + parameter
+				if reported[key] {
+					continue
+				}
+				reported[key] = true
+
+				syncs = append(syncs, CookieSync{
+					Cookie:            cookie.Name,
+					SourceDomain:      source,
+					DestinationDomain: request.Host,
+					Parameter:         parameter,
+					RequestURL:        request.URL,
+				})
+			}
+		}
+	}
+
+	return syncs
+}
+
+// Function: Sync Tokens
+// Operation: Splits a cookie value into the parts that could be identifiers: the
+// value, each tail after a separator and each part, e.g. "GA1.2.987654321.1700000000"
+// also yields "987654321.1700000000" and "987654321". Parts shorter than
+// MIN_SYNC_VALUE_LENGTH or that look like Unix timestamps are left out.
+// Return: []string, the whole value first
+func syncTokens(value string) []string {
+	if decoded, err := url.QueryUnescape(value); err == nil {
+		value = decoded
+	}
+
+	var tokens []string
+	seen := make(map[string]bool)
+	add := func(token string) {
+		if len(token) < MIN_SYNC_VALUE_LENGTH || seen[token] || isTimestamp(token) {
+			return
+		}
+		seen[token] = true
+		tokens = append(tokens, token)
+	}
+
+	isSeparator := func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_')
+	}
+
+	add(value)
+	for i, r := range value {
+		if isSeparator(r) {
+			add(value[i+1:])
+		}
+	}
+	for _, part := range strings.FieldsFunc(value, isSeparator) {
+		add(part)
+	}
+
+	return tokens
+}
+
+// Function: Is Timestamp
+// Operation: Reports whether the token is a Unix time in seconds or milliseconds.
+// Return: bool
+func isTimestamp(token string) bool {
+	if len(token) != 10 && len(token) != 13 {
+		return false
+	}
+	for _, r := range token {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return token[0] == '1'
+}
+
+// Function: Find Tokens
+// Operation: Lists where in the request any of the tokens appear.
+// Return: []string of parameters, sorted
+func findTokens(request NetworkRequest, tokens []string) []string {
+	found := make(map[string]bool)
+
+	parsedURL, err := url.Parse(request.URL)
+	if err == nil {
+		for name, values := range parsedURL.Query() {
+			for _, value := range values {
+				if containsAny(value, tokens) {
+					found[name] = true
+				}
+			}
+		}
+		if containsAny(parsedURL.Path, tokens) {
+			found["path"] = true
+		}
+	}
+
+	for name, value := range request.RequestHeaders {
+		// The cookie header carries the destination's own cookies, and the referer
+		// the URL of the page or stylesheet, which is a request of its own.
+		if name == "cookie" || name == "referer" {
+			continue
+		}
+		if containsAny(value, tokens) {
+			found["header:"+name] = true
+		}
+	}
+
+	if request.Body != "" && containsAny(request.Body, tokens) {
+		// Name the form fields when the body is form encoded.
+		inField := false
+		if form, err := url.ParseQuery(request.Body); err == nil {
+			for name, values := range form {
+				for _, value := range values {
+					if containsAny(value, tokens) {
+						found["body:"+name] = true
+						inField = true
+					}
+				}
+			}
+		}
+		if !inField {
+			found["body"] = true
+		}
+	}
+
+	parameters := make([]string, 0, len(found))
+	for parameter := range found {
+		parameters = append(parameters, parameter)
+	}
+	sort.Strings(parameters)
+
+	return parameters
+}
+
+// Function: Sync Partners
+// Operation: Counts the distinct destination hosts that received synced values.
+// Return: int
+func syncPartners(syncs []CookieSync) int {
+	partners := make(map[string]bool)
+	for _, sync := range syncs {
+		partners[sync.DestinationDomain] = true
+	}
+	return len(partners)
+}
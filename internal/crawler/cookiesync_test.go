@@ -0,0 +1,138 @@
+package crawler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSyncTokens(t *testing.T) {
+	tests := []struct {
+		value  string
+		tokens []string
+	}{
+		{"GA1.2.987654321.1700000000", []string{"GA1.2.987654321.1700000000", "2.987654321.1700000000",
+			"987654321.1700000000", "987654321"}},
+		{"id%3Dabcdef123456%7Cx", []string{"id=abcdef123456|x", "abcdef123456|x", "abcdef123456"}},
+		{"%zz12345678", []string{"%zz12345678", "zz12345678"}},
+		{"3f2c9a1e-7b4d-4c8e-9f0a-1b2c3d4e5f60", []string{"3f2c9a1e-7b4d-4c8e-9f0a-1b2c3d4e5f60"}},
+		{"abcdefgh.abcdefgh", []string{"abcdefgh.abcdefgh", "abcdefgh"}},
+		{"uid_42:1234567", []string{"uid_42:1234567"}},
+		{"1700000000000", nil},
+		{"abc", nil},
+		{"", nil},
+	}
+
+	for _, test := range tests {
+		if tokens := syncTokens(test.value); !reflect.DeepEqual(tokens, test.tokens) {
+			t.Errorf("syncTokens(%q) = %q, want %q", test.value, tokens, test.tokens)
+		}
+	}
+}
+
+func TestIsTimestamp(t *testing.T) {
+	tests := []struct {
+		token string
+		want  bool
+	}{
+		{"1700000000", true},
+		{"1700000000123", true},
+		{"2700000000", false},
+		{"170000000", false},
+		{"170000000a", false},
+		{"17000000001", false},
+	}
+
+	for _, test := range tests {
+		if got := isTimestamp(test.token); got != test.want {
+			t.Errorf("isTimestamp(%q) = %t, want %t", test.token, got, test.want)
+		}
+	}
+}
+
+func TestDetectCookieSyncs(t *testing.T) {
+	const uid = "a1b2c3d4e5f6"
+	cookies := []Cookie{
+		{Name: "uid", Value: uid, Domain: ".tracker.com"},
+		{Name: "_ga", Value: "GA1.2.987654321.1700000000", Domain: ".example.com"},
+		{Name: "lang", Value: "en", Domain: "example.com"}, // Too short to sync.
+	}
+
+	tests := []struct {
+		name    string
+		request NetworkRequest
+		syncs   []CookieSync
+	}{
+		{
+			name:    "same-site destination",
+			request: NetworkRequest{URL: "https://pixel.tracker.com/sync?uid=" + uid, Host: "pixel.tracker.com"},
+		},
+		{
+			name: "query parameter",
+			request: NetworkRequest{URL: "https://match.partner.net/match?partner_uid=" + uid + "&r=1",
+				Host: "match.partner.net"},
+			syncs: []CookieSync{{Cookie: "uid", SourceDomain: "tracker.com", DestinationDomain: "match.partner.net",
+				Parameter: "partner_uid", RequestURL: "https://match.partner.net/match?partner_uid=" + uid + "&r=1"}},
+		},
+		{
+			name:    "path",
+			request: NetworkRequest{URL: "https://match.partner.net/u/" + uid + "/pixel.gif", Host: "match.partner.net"},
+			syncs: []CookieSync{{Cookie: "uid", SourceDomain: "tracker.com", DestinationDomain: "match.partner.net",
+				Parameter: "path", RequestURL: "https://match.partner.net/u/" + uid + "/pixel.gif"}},
+		},
+		{
+			name: "part of a cookie value in a form field",
+			request: NetworkRequest{URL: "https://collect.analytics.io/c", Host: "collect.analytics.io",
+				Body: "cid=987654321&v=2"},
+			syncs: []CookieSync{{Cookie: "_ga", SourceDomain: "example.com", DestinationDomain: "collect.analytics.io",
+				Parameter: "body:cid", RequestURL: "https://collect.analytics.io/c"}},
+		},
+		{
+			name: "unstructured body and custom header",
+			request: NetworkRequest{URL: "https://collect.analytics.io/j", Host: "collect.analytics.io",
+				Body: `{"id":"` + uid + `"}`, RequestHeaders: map[string]string{"x-user": uid}},
+			syncs: []CookieSync{
+				{Cookie: "uid", SourceDomain: "tracker.com", DestinationDomain: "collect.analytics.io",
+					Parameter: "body", RequestURL: "https://collect.analytics.io/j"},
+				{Cookie: "uid", SourceDomain: "tracker.com", DestinationDomain: "collect.analytics.io",
+					Parameter: "header:x-user", RequestURL: "https://collect.analytics.io/j"},
+			},
+		},
+		{
+			name: "cookie and referer headers",
+			request: NetworkRequest{URL: "https://cdn.partner.net/lib.js", Host: "cdn.partner.net",
+				RequestHeaders: map[string]string{
+					"cookie":  "uid=" + uid,
+					"referer": "https://www.example.com/landing?uid=" + uid,
+				}},
+		},
+	}
+
+	for _, test := range tests {
+		if syncs := detectCookieSyncs(cookies, []NetworkRequest{test.request}); !reflect.DeepEqual(syncs, test.syncs) {
+			t.Errorf("%s: syncs %+v, want %+v", test.name, syncs, test.syncs)
+		}
+	}
+
+	// Each cookie, destination and parameter is reported once, with the first request.
+	requests := []NetworkRequest{
+		{URL: "https://match.partner.net/a?id=" + uid, Host: "match.partner.net"},
+		{URL: "https://match.partner.net/b?id=" + uid, Host: "match.partner.net"},
+		{URL: "https://match.partner.net/c?other=" + uid, Host: "match.partner.net"},
+		{URL: "https://sync.exchange.org/a?id=" + uid, Host: "sync.exchange.org"},
+	}
+	var got []string
+	for _, sync := range detectCookieSyncs(cookies, requests) {
+		got = append(got, sync.DestinationDomain+" "+sync.Parameter+" "+sync.RequestURL)
+	}
+	want := []string{
+		"match.partner.net id https://match.partner.net/a?id=" + uid,
+		"match.partner.net other https://match.partner.net/c?other=" + uid,
+		"sync.exchange.org id https://sync.exchange.org/a?id=" + uid,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("deduplicated syncs %q, want %q", got, want)
+	}
+	if partners := syncPartners(detectCookieSyncs(cookies, requests)); partners != 2 {
+		t.Errorf("%d sync partners, want 2", partners)
+	}
+}
@@ -1,10 +1,9 @@
 package crawler
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"os"
 	"strings"
@@ -35,6 +34,37 @@ type Result struct {
 // Uses type Cookie for each entry.
 type CookiesList struct {
 	List map[string][]Cookie
+
+	// Pages visited in order, with the cookies present after each one.
+	Pages []PageCookies
+
+	// Partial is set when the crawl was cancelled before it finished.
+	Partial bool
+
+	// How navigation to the landing page ended, including retries.
+	Outcome CrawlOutcome
+
+	// Cookie counts at every snapshot, relative to the start of navigation.
+	Timeline []TimelinePoint
+
+	// Every request made during the visit, in the order it was sent.
+	Requests []NetworkRequest
+
+	// Web storage of every origin seen in a frame, at the end of the visit.
+	Storage []OriginStorage
+
+	// Fingerprinting API calls, once per API and calling script.
+	Fingerprinting []FingerprintCall
+
+	// Cookie values sent to a different site than the one that set them.
+	Syncs []CookieSync
+
+	// Main frame navigations that were redirected, every hop in order.
+	Redirects []RedirectChain
+
+	// Subresources of HTTPS pages referenced over HTTP, and forms that submit over HTTP.
+	MixedContent  []MixedContent
+	InsecureForms []InsecureForm
 }
 
 // Cookie: Represents the privacy characteristics of the collected cookies
@@ -48,6 +78,34 @@ type Cookie struct {
 	Secure       bool
 	SameSite     string
 	IsFirstParty bool
+
+	// Page of the visit where the cookie was first seen.
+	FirstSeenPage string
+
+	// When the cookie first appeared and when its value last changed,
+	// relative to the start of navigation.
+	FirstSeenAt   time.Duration
+	LastChangedAt time.Duration
+
+	// How the cookie was set, and the response or script URL that set it.
+	Source CookieSource
+	SetBy  string
+
+	// Category of the cookie domain in the tracker lists, empty if not listed.
+	Tracker TrackerCategory
+
+	// Vendor, purpose and typical lifetime from the cookie catalog.
+	// Vendor is empty and Purpose is PurposeUnknown for cookies not in the catalog.
+	Vendor          string
+	Purpose         CookiePurpose
+	TypicalLifetime string
+
+	// Tracker in the CNAME chain of a first-party looking cookie domain.
+	// Such cookies are counted as third-party.
+	CloakedBy string
+
+	// First plain HTTP request of the visit the cookie was in scope of, so sent unencrypted.
+	InsecureRequest string
 }
 
 // Privacy Metric: Represents the privacy fields to consider
@@ -72,6 +130,155 @@ type PrivacyMetric struct {
 
 	TotalSessionCookies    int
 	TotalPersistentCookies int
+
+	// Third-party cookies on the tracker lists, by category, and those that are not.
+	// CDN cookies are counted by category but are not tracker cookies.
+	TrackerLists              string // Version of the lists used.
+	TotalTrackerCookies       int
+	TotalNonTrackerThirdParty int
+	TrackerCookiesByCategory  map[TrackerCategory]int
+
+	// Cookies by purpose in the cookie catalog.
+	CookieCatalog    string // Version of the catalog used.
+	CookiesByPurpose map[CookiePurpose]int
+
+	PagesVisited int
+
+	// Partial is set when the crawl was cancelled before it finished.
+	Partial bool
+
+	// How navigation to the landing page ended. Failed crawls should be left out of totals.
+	Outcome CrawlOutcome
+
+	// Wait strategy used and the time actually waited, summed over all pages.
+	// UnsettledWaits counts pages where the wait hit its maximum or was cancelled.
+	WaitMode       WaitMode
+	WaitTime       time.Duration
+	UnsettledWaits int
+
+	// Consent banner found on the landing page and what was done with it.
+	Consent ConsentResult
+
+	// Whether the crawl sent the GPC and DNT privacy signals.
+	PrivacySignals bool
+
+	// Requests made during the visit, and the third-party hosts they went to.
+	TotalRequests      int
+	ThirdPartyRequests int
+	TrackerRequests    int
+	TotalRequestBytes  int
+	ThirdPartyHosts    []HostTraffic
+
+	// Web storage at the end of the visit. Sizes are value lengths in characters.
+	StorageOrigins           []OriginStorage // Origins with at least one key or database.
+	ThirdPartyStorageOrigins int
+	LocalStorageKeys         int
+	LocalStorageSize         int
+	SessionStorageKeys       int
+	SessionStorageSize       int
+	IndexedDBDatabases       int
+
+	// How cookies were set. ThirdPartyScriptCookies counts cookies written by
+	// scripts loaded from a third-party host.
+	HTTPCookies             int
+	ScriptCookies           int
+	UnknownSourceCookies    int
+	ThirdPartyScriptCookies int
+
+	// Fingerprinting: distinct API calls by script, the scripts that fingerprint
+	// and a score from 0 to 100 weighted by the API categories they use.
+	FingerprintCalls      int
+	FingerprintScore      int
+	FingerprintingScripts []FingerprintScript
+
+	// Security and privacy response headers of the landing page, fetched outside the browser.
+	Headers HeaderAudit
+
+	// gpc.json, ads.txt, app-ads.txt, sellers.json and security.txt of the site.
+	WellKnown WellKnownFiles
+
+	// TLS connection and certificate of the landing page.
+	TLS TLSInfo
+
+	// Mixed content of the pages visited, forms that submit over HTTP and the
+	// cookies in scope of a request sent over HTTP.
+	MixedContent       []MixedContent
+	ActiveMixedContent int
+	InsecureForms      []InsecureForm
+	InsecureCookies    int
+
+	// Redirected navigations of the crawl, the landing page fetched outside the
+	// browser, and the hosts that set cookies while bouncing a navigation.
+	Redirects     []RedirectChain
+	HTTPRedirects RedirectChain
+	BounceHosts   []string
+
+	// Cookie syncing: each value sent to another site, and how many hosts received one.
+	CookieSyncs  []CookieSync
+	SyncPartners int
+
+	// CNAME cloaking: first-party looking hosts that resolve to trackers, and the
+	// cookies and requests reclassified as third-party because of them.
+	CloakedHosts    []CloakedHost
+	CloakedCookies  int
+	CloakedRequests int
+}
+
+// Crawl Options: Represents the settings for a single browser crawl.
+type CrawlOptions struct {
+	Browser  string
+	Hidden   bool // Ignored when Pool is set; the pool decides.
+	URL      string
+	Duration int // Milliseconds to wait after navigation, on every page, for WaitFixed.
+	Verbose  bool
+
+	// Wait decides how long to stay on each page. The zero value is WaitFixed.
+	Wait WaitStrategy
+
+	// Milliseconds between cookie snapshots for the timeline, 0 only
+	// snapshots once per page.
+	SnapshotInterval int
+
+	// Consent decides what to do with a consent banner, the zero value is
+	// ConsentNoAction. ConsentRules are read from CONSENTFILE when nil.
+	Consent      ConsentMode
+	ConsentRules *ConsentRules
+
+	// Depth of same-site links to follow from the landing page, 0 visits only
+	// the landing page. MaxPages caps the pages visited, 0 uses DEFAULT_MAX_PAGES.
+	Depth    int
+	MaxPages int
+
+	// Pool supplies the browser. When nil, a pool is created for this crawl only.
+	Pool *BrowserPool
+
+	// Retry decides which navigation failures are retried. When nil, DefaultRetryPolicy is used.
+	Retry *RetryPolicy
+
+	// Trackers classify hosts and cookie domains. When nil, they are read from TRACKERFILE.
+	Trackers *TrackerLists
+
+	// Catalog labels cookies by name. When nil, it is read from COOKIECATALOGFILE.
+	Catalog *CookieCatalog
+
+	// CNAME resolves first-party cookie domains and request hosts to find
+	// cloaked trackers. When nil, no DNS lookups are made.
+	CNAME *CNAMEResolver
+
+	// PrivacySignals sends Sec-GPC and DNT and sets navigator.globalPrivacyControl.
+	// GPCCompare crawls the site without and with the signals; see CompareGPC.
+	PrivacySignals bool
+	GPCCompare     bool
+
+	// HSTSPreload tells which hosts browsers force to HTTPS. When nil, it is read from HSTSPRELOADFILE.
+	HSTSPreload *HSTSPreloadList
+
+	// HTTPClient sends the requests made outside the browser. When nil, RunPrivacyCrawlWithOptions
+	// reads its settings from HTTPCLIENTFILE; the default settings are used if that fails.
+	HTTPClient *HTTPClient
+
+	// PrintReports prints the consent, GPC and metrics reports as well as appending them to their files.
+	PrintReports bool
 }
 
 // Possible additions to PrivacyMetric
@@ -99,6 +306,8 @@ const SAMESITENONE_THRESHOLD float64 = 50.0   // 50%
 const SAMESITEUNSET_THRESHOLD float64 = 50.0  // 50%
 const SESSION_THRESHOLD float64 = 50.0        // 50%
 const PERSISTENT_THRESHOLD float64 = 50.0     // 50%
+const FINGERPRINT_THRESHOLD float64 = 50.0    // score of 50 out of 100
+const HEADER_THRESHOLD float64 = 70.0         // score of 70 out of 100
 
 // ---- Functions ---- //
 
@@ -199,98 +408,112 @@ func VerifyTargetBrowser(browsers map[string]string, selectedBrowser string, ver
 }
 
 // Fucntion: Fetch URL
-// Operation: Connect and returns header from selected URL.
+// Operation: Connect and returns header from selected URL, with the shared
+// client of the default HTTP client configuration.
 // Return: Header (map), Body (map), Status Code (int), Error
 func FetchPacket(url string, userAgent string, verbose *bool) (map[string][]string, []byte, int, error) {
+	var client *HTTPClient
+	return client.FetchPacket(context.Background(), url, userAgent, verbose)
+}
 
-	// Create a new HTTP client.
-	client := &http.Client{
-		// Timeout if cannot connect.
-		Timeout: time.Second * 10,
-	}
-
-	// Create a new request.
-	request, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, nil, 0, fmt.Errorf("error creating request: %v", err)
-	}
+// Function: Fetch Cookies
+// Operation: Connect to url with browser, creates cookies using playwright
+// to fully generate all cookies due to Javascript delys.
+// Return: A list of cookies collected and stored in a struct (*CookiesList)
+func FetchCookies(browser string, isHidden bool, url string, privacyMetrics *PrivacyMetric, verbose *bool, duration int) *CookiesList {
+	return FetchCookiesWithOptions(context.Background(), CrawlOptions{
+		Browser:  browser,
+		Hidden:   isHidden,
+		URL:      url,
+		Duration: duration,
+		Verbose:  *verbose,
+	}, privacyMetrics)
+}
 
-	// Set the User-Agent header based on browser.
-	request.Header.Set("User-Agent", userAgent)
+// Function: Fetch Cookies With Options
+// Operation: Same as FetchCookies, but takes its settings from CrawlOptions. The crawl
+// runs in a fresh context from options.Pool, or from a pool made for this crawl only.
+// When ctx is cancelled or its deadline passes, the page is closed, the cookies
+// collected so far are returned and the list and metrics are marked Partial.
+// Return: A list of cookies collected and stored in a struct (*CookiesList)
+func FetchCookiesWithOptions(ctx context.Context, options CrawlOptions, privacyMetrics *PrivacyMetric) *CookiesList {
+	browser := options.Browser
+	isHidden := options.Hidden
+	url := options.URL
+	duration := options.Duration
+	verbose := &options.Verbose
 
-	// Make the request.
-	response, err := client.Do(request)
-	if err != nil {
-		return nil, nil, 0, fmt.Errorf("error making request: %v", err)
+	// Store cookies in a struc, organized
+	collectedCookies := CookiesList{
+		List: make(map[string][]Cookie),
 	}
 
-	// Cleanup on response.
-	defer response.Body.Close()
-
-	// Read the body.
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, nil, 0, fmt.Errorf("error reading response body: %v", err)
+	// Failures before navigation are recorded as the outcome of the crawl.
+	fail := func(category OutcomeCategory, err error) *CookiesList {
+		fmt.Printf("%v\n", err)
+		collectedCookies.Outcome = CrawlOutcome{Category: category, Error: err.Error()}
+		privacyMetrics.Outcome = collectedCookies.Outcome
+		return &collectedCookies
 	}
 
-	// Cleanup on body.
-	response.Body.Close()
-
-	// - Verbose output - //
-	if *verbose {
-		fmt.Printf("\n-- HTTP Packet: Header for %s --\n", url)
-		fmt.Printf("Status: %s\n", response.Status)
-
-		fmt.Printf("\n-- HTTP Packet: Body for %s --\n\n", url)
-		fmt.Printf("Body: %s\n", string(body))
+	if ctx.Err() != nil {
+		return fail(OutcomeCancelled, fmt.Errorf("crawl of %s cancelled before start: %v", url, ctx.Err()))
 	}
 
-	// Return the headers and status code.
-	return response.Header, body, response.StatusCode, nil
-}
+	privacyMetrics.WaitMode = options.Wait.withDefaults().Mode
 
-// Function: Fetch Cookies
-// Operation: Connect to url with browser, creates cookies using playwright
-// to fully generate all cookies due to Javascript delys.
-// Return: A list of cookies collected and stored in a struct (*CookiesList)
-func FetchCookies(browser string, isHidden bool, url string, privacyMetrics *PrivacyMetric, verbose *bool, duration int) *CookiesList {
+	// Consent banner handling, compare is done a level up by running each mode.
+	consentMode := options.Consent
+	if consentMode == "" || consentMode == ConsentCompare {
+		consentMode = ConsentNoAction
+	}
+	privacyMetrics.Consent = ConsentResult{Mode: consentMode}
+	consentRules := options.ConsentRules
+	if consentRules == nil {
+		var err error
+		consentRules, err = ReadConsentRules(CONSENTFILE)
+		if err != nil {
+			fmt.Printf("%v, consent banners will not be detected\n", err)
+		}
+	}
 
-	// - Run Playwright - //
-	pw, err := playwright.Run()
-	if err != nil {
-		fmt.Printf("could not lauch playwright: %v", err)
-		return nil
+	// Tracker lists, the crawl goes on unclassified without them.
+	trackers := options.Trackers
+	if trackers == nil {
+		var err error
+		trackers, err = ReadTrackerLists(TRACKERFILE)
+		if err != nil {
+			fmt.Printf("%v, trackers will not be classified\n", err)
+		}
 	}
-	defer pw.Stop()
-
-	// Declare launcher ahead of time
-	var launcher playwright.Browser
-
-	// launching specific browser (edge is missing)
-	if browser == "chrome" {
-		launcher, err = pw.Chromium.Launch(playwright.BrowserTypeLaunchOptions{
-			Headless: playwright.Bool(isHidden),
-		})
-	} else if browser == "firefox" {
-		launcher, err = pw.Firefox.Launch(playwright.BrowserTypeLaunchOptions{
-			Headless: playwright.Bool(isHidden),
-		})
-	} else if browser == "webkit" { // Changed from safari
-		launcher, err = pw.WebKit.Launch(playwright.BrowserTypeLaunchOptions{
-			Headless: playwright.Bool(isHidden),
-		})
-	} else if browser == "chromium" {
-		launcher, err = pw.Chromium.Launch(playwright.BrowserTypeLaunchOptions{
-			Channel:  playwright.String("chromium"),
-			Headless: playwright.Bool(isHidden),
-		})
-	} else {
-		fmt.Printf("Browser %s is not compativle\n", browser)
-		return nil
+	privacyMetrics.TrackerLists = trackers.versionOf()
+
+	// Cookie catalog, cookies stay unlabelled without it.
+	catalog := options.Catalog
+	if catalog == nil {
+		var err error
+		catalog, err = ReadCookieCatalog(COOKIECATALOGFILE)
+		if err != nil {
+			fmt.Printf("%v, cookies will not be labelled\n", err)
+		}
 	}
-	if err != nil {
-		fmt.Printf("could not launch browser: %s\n", browser)
-		return nil
+	privacyMetrics.CookieCatalog = catalog.versionOf()
+
+	// Retry policy for navigation failures.
+	policy := DefaultRetryPolicy()
+	if options.Retry != nil {
+		policy = *options.Retry
+	}
+
+	// - Get Browser Pool - //
+	pool := options.Pool
+	if pool == nil {
+		var err error
+		pool, err = NewBrowserPool(isHidden, *verbose)
+		if err != nil {
+			return fail(OutcomeBrowser, err)
+		}
+		defer pool.Close()
 	}
 
 	if *verbose {
@@ -303,76 +526,288 @@ func FetchCookies(browser string, isHidden bool, url string, privacyMetrics *Pri
 	}
 
 	// Create the context for the broswer
-	context, err := launcher.NewContext() // creates an isolated browsers contents
+	browserContext, err := pool.NewContext(browser) // creates an isolated browsers contents
 	if err != nil {
-		fmt.Printf("could not create context: %v", err)
-		return nil
+		return fail(OutcomeBrowser, err)
 	}
+	defer pool.CloseContext(browserContext)
 
-	// Open up a new tab from the context
-	page, err := context.NewPage() // Add a new Tab
-	if err != nil {
-		fmt.Printf("could not create a new Tab: %v", err)
-		return nil
+	// Send the GPC and DNT privacy signals from every page of the context.
+	if options.PrivacySignals {
+		err = sendPrivacySignals(browserContext)
+		if err != nil {
+			return fail(OutcomeBrowser, err)
+		}
 	}
+	privacyMetrics.PrivacySignals = options.PrivacySignals
+
+	// Record every request of the context, including those of frames.
+	network := newNetworkRecorder(browserContext, url)
 
-	// Navigate to the desired URL
-	_, err = page.Goto(url)
+	// Record which script writes each cookie, in every frame.
+	provenance, err := newProvenanceRecorder(browserContext)
 	if err != nil {
-		fmt.Printf("could not go to url page: %v", err)
+		return fail(OutcomeBrowser, err)
 	}
 
-	// Wait for a few seconds for JS to run and set cookies
-	page.WaitForTimeout(float64(duration))
+	// Record fingerprinting API calls, in every frame.
+	fingerprinting, err := newFingerprintRecorder(browserContext)
+	if err != nil {
+		return fail(OutcomeBrowser, err)
+	}
 
-	// Get cookies
-	cookies, err := context.Cookies()
+	// Open up a new tab from the context
+	page, err := browserContext.NewPage() // Add a new Tab
 	if err != nil {
-		fmt.Printf("could not get cookies: %v", err)
-		return nil
+		return fail(OutcomeBrowser, fmt.Errorf("could not create a new Tab: %v", err))
 	}
-	if len(cookies) == 0 {
-		fmt.Println("No cookies were returned.")
-		return nil
+
+	// Abort blocked Playwright calls on cancel and keep navigation within the deadline.
+	stopWatch := closeOnCancel(ctx, page)
+	defer stopWatch()
+
+	// Every cookie seen during the visit, latest value wins.
+	seen := make(map[string]Cookie)
+	var seenOrder []string
+
+	// Web storage by origin, captured after every page.
+	storage := make(map[string]OriginStorage)
+
+	// Mixed content by URL and insecure forms by target, captured after every page.
+	mixed := make(map[string]MixedContent)
+	forms := make(map[string]InsecureForm)
+
+	// Timeline of cookie appearance, starting at navigation.
+	timeline := newCookieTimeline(url)
+	stopPoll := func() {}
+	if options.SnapshotInterval > 0 {
+		stopPoll = timeline.poll(ctx, browserContext, options.SnapshotInterval)
 	}
 
-	// Store cookies in a struc, organized
-	collectedCookies := CookiesList{
-		List: make(map[string][]Cookie),
+	// - Visit pages breadth-first, landing page first - //
+	queue := []pageTarget{{url: url, depth: 0}}
+	queued := map[string]bool{normalizeLink(url): true}
+	maxPages := options.pageBudget()
+
+	for len(queue) > 0 && len(collectedCookies.Pages) < maxPages {
+		if ctx.Err() != nil {
+			collectedCookies.Partial = true
+			break
+		}
+
+		target := queue[0]
+		queue = queue[1:]
+
+		if *verbose && target.depth > 0 {
+			fmt.Printf("Visiting %s (depth %d)...\n", target.url, target.depth)
+		}
+
+		// Navigate to the desired URL
+		outcome := navigate(ctx, page, target.url, policy, *verbose)
+		if target.depth == 0 {
+			// The landing page decides the outcome of the whole crawl.
+			collectedCookies.Outcome = outcome
+		}
+		if outcome.Category == OutcomeCancelled {
+			collectedCookies.Partial = true
+		} else if outcome.IsFailure() {
+			fmt.Printf("could not go to url page %s: [%s] %s\n", target.url, outcome.Category, outcome.Error)
+			if target.depth == 0 {
+				break
+			}
+			continue
+		}
+
+		// Act on the consent banner of the landing page before waiting.
+		if target.depth == 0 && ctx.Err() == nil {
+			privacyMetrics.Consent = handleConsent(ctx, page, consentRules, consentMode, *verbose)
+		}
+
+		visit := PageCookies{URL: target.url, Depth: target.depth}
+
+		// Wait for JS to run and set cookies
+		if ctx.Err() == nil {
+			visit.Wait = waitForPage(ctx, page, browserContext, options.Wait, duration)
+			privacyMetrics.WaitTime += visit.Wait.Waited
+			if !visit.Wait.Settled {
+				privacyMetrics.UnsettledWaits++
+			}
+		}
+		if ctx.Err() != nil {
+			collectedCookies.Partial = true
+		}
+
+		// Get cookies
+		cookies, err := browserContext.Cookies()
+		if err != nil {
+			fmt.Printf("could not get cookies: %v\n", err)
+			if !collectedCookies.Partial {
+				collectedCookies.Outcome.Category = OutcomeBrowser
+				collectedCookies.Outcome.Error = err.Error()
+			}
+			break
+		}
+		timeline.record(cookies)
+
+		// Record the cookies present after this page.
+		for _, c := range cookies {
+			cookie := convertCookie(c, url)
+			key := cookieKey(cookie)
+
+			if previous, ok := seen[key]; ok {
+				cookie.FirstSeenPage = previous.FirstSeenPage
+			} else {
+				cookie.FirstSeenPage = target.url
+				seenOrder = append(seenOrder, key)
+				visit.NewCookies++
+			}
+			seen[key] = cookie
+			visit.Cookies = append(visit.Cookies, cookie)
+		}
+		collectedCookies.Pages = append(collectedCookies.Pages, visit)
+
+		if collectedCookies.Partial {
+			break
+		}
+
+		// Read web storage of the page and its frames.
+		captureStorage(page, url, storage)
+
+		// Read the HTTP subresources and form targets of the page and its frames.
+		captureMixedContent(page, mixed, forms)
+
+		// Queue same-site links for the next depth.
+		if target.depth < options.Depth {
+			for _, link := range extractSameSiteLinks(page, url) {
+				key := normalizeLink(link)
+				if queued[key] {
+					continue
+				}
+				queued[key] = true
+				queue = append(queue, pageTarget{url: link, depth: target.depth + 1})
+			}
+		}
 	}
 
-	collectedCount := 0
-	for _, c := range cookies {
-		// Check for first or third part
-		isFirst := isFirstParty(c.Domain, url) // check if url and cookie Domain are the same
-
-		// Store fields inside of a cookie
-		cookie := Cookie{
-			Name:         c.Name,
-			Value:        c.Value,
-			Domain:       c.Domain,
-			Path:         c.Path,
-			Expires:      float64(c.Expires),
-			HttpOnly:     c.HttpOnly,
-			Secure:       c.Secure,
-			SameSite:     string(*c.SameSite),
-			IsFirstParty: isFirst,
+	stopPoll()
+	collectedCookies.Timeline = timeline.series()
+	collectedCookies.Requests = network.collect(ctx)
+	collectedCookies.Redirects = network.redirectChains(collectedCookies.Requests)
+	privacyMetrics.Redirects = collectedCookies.Redirects
+	privacyMetrics.BounceHosts = bounceHosts(collectedCookies.Redirects)
+
+	// Add what went out over HTTP to the mixed content found in the pages.
+	insecure := insecureRequests(collectedCookies.Requests)
+	addInsecureRequests(mixed, insecure)
+	collectedCookies.MixedContent = mixedContentList(mixed)
+	collectedCookies.InsecureForms = insecureFormList(forms)
+	privacyMetrics.MixedContent = collectedCookies.MixedContent
+	privacyMetrics.InsecureForms = collectedCookies.InsecureForms
+	for _, resource := range collectedCookies.MixedContent {
+		if resource.Active {
+			privacyMetrics.ActiveMixedContent++
+		}
+	}
+
+	// Reclassify first-party hosts whose CNAME chain ends at a tracker.
+	var cloaked map[string]CloakedHost
+	if options.CNAME != nil {
+		var hosts []string
+		for _, request := range collectedCookies.Requests {
+			if request.IsFirstParty {
+				hosts = append(hosts, request.Host)
+			}
 		}
+		for _, key := range seenOrder {
+			if seen[key].IsFirstParty {
+				hosts = append(hosts, seen[key].Domain)
+			}
+		}
+
+		cloaked = detectCloaking(ctx, options.CNAME, hosts, url, trackers)
+		markCloakedRequests(collectedCookies.Requests, cloaked)
+		privacyMetrics.CloakedHosts = cloakedList(cloaked, hosts)
+	}
+	addRequestsToMetric(privacyMetrics, collectedCookies.Requests, trackers)
+	collectedCookies.Storage = storageList(storage)
+	addStorageToMetric(privacyMetrics, collectedCookies.Storage)
+	collectedCookies.Fingerprinting = fingerprinting.list()
+	addFingerprintingToMetric(privacyMetrics, collectedCookies.Fingerprinting, url)
+
+	if collectedCookies.Partial {
+		privacyMetrics.Partial = true
+		fmt.Printf("crawl of %s stopped early: %v\n", url, ctx.Err())
+	}
+	privacyMetrics.PagesVisited += len(collectedCookies.Pages)
+
+	// A site that loaded but set no cookies is a result, not a failure.
+	if collectedCookies.Outcome.Category == OutcomeOK && len(seen) == 0 {
+		fmt.Println("No cookies were returned.")
+		collectedCookies.Outcome.Category = OutcomeNoCookies
+	}
+	privacyMetrics.Outcome = collectedCookies.Outcome
+
+	// Attribute cookies to Set-Cookie headers or script writes.
+	sources := provenance.sources(collectedCookies.Requests)
+
+	collectedCount := 0
+	for _, key := range seenOrder {
+		cookie := attributeCookie(timeline.stamp(seen[key]), sources)
+		cookie.Tracker = trackers.Classify(cookie.Domain)
+		cookie = markCloakedCookie(cookie, cloaked)
+		cookie = markInsecureCookie(cookie, insecure)
+		cookie = catalog.labelCookie(cookie)
+
 		// Map to Cookie Key
-		collectedCookies.List[c.Domain] = append(collectedCookies.List[c.Domain], cookie)
+		collectedCookies.List[cookie.Domain] = append(collectedCookies.List[cookie.Domain], cookie)
 		collectedCount++
 
 		addToMetric(privacyMetrics, cookie, url)
+	}
 
+	// Look for cookie values sent on to other sites.
+	var collected []Cookie
+	for _, cookies := range collectedCookies.List {
+		collected = append(collected, cookies...)
 	}
+	collectedCookies.Syncs = detectCookieSyncs(collected, collectedCookies.Requests)
+	privacyMetrics.CookieSyncs = collectedCookies.Syncs
+	privacyMetrics.SyncPartners = syncPartners(collectedCookies.Syncs)
 
 	if *verbose {
+		fmt.Printf("*** Pages Visited: %d ***\n", len(collectedCookies.Pages))
 		fmt.Printf("*** Cookies Collected: %d ***\n", collectedCount)
 	}
 
 	return &collectedCookies
 }
 
+// Function: Convert Cookie
+// Operation: Copies a Playwright cookie into a Cookie, marking its party against the url
+// Return: Cookie
+func convertCookie(c playwright.Cookie, url string) Cookie {
+	// Check for first or third part
+	isFirst := isFirstParty(c.Domain, url) // check if url and cookie Domain are the same
+
+	sameSite := ""
+	if c.SameSite != nil {
+		sameSite = string(*c.SameSite)
+	}
+
+	// Store fields inside of a cookie
+	return Cookie{
+		Name:         c.Name,
+		Value:        c.Value,
+		Domain:       c.Domain,
+		Path:         c.Path,
+		Expires:      float64(c.Expires),
+		HttpOnly:     c.HttpOnly,
+		Secure:       c.Secure,
+		SameSite:     sameSite,
+		IsFirstParty: isFirst,
+	}
+}
+
 // Function: Add to Privacy Metric
 // Operation: Adds specific data from cookies to use for later analysis
 // Return: None
@@ -380,19 +815,44 @@ func addToMetric(privacyMetrics *PrivacyMetric, cookie Cookie, url string) {
 	// Add 1 to cookie total
 	privacyMetrics.TotalCookies++
 
-	// Check for cookie party
-	if isFirstParty(cookie.Domain, url) {
+	// Check for cookie party, as classified when the cookie was collected
+	if cookie.IsFirstParty {
 		privacyMetrics.TotalFirstParty++
 	} else {
 		privacyMetrics.TotalThirdParty++
+
+		// Check for tracker
+		if cookie.Tracker.IsTracker() {
+			privacyMetrics.TotalTrackerCookies++
+		} else {
+			privacyMetrics.TotalNonTrackerThirdParty++
+		}
+		if cookie.Tracker != "" {
+			if privacyMetrics.TrackerCookiesByCategory == nil {
+				privacyMetrics.TrackerCookiesByCategory = make(map[TrackerCategory]int)
+			}
+			privacyMetrics.TrackerCookiesByCategory[cookie.Tracker]++
+		}
+		if cookie.CloakedBy != "" {
+			privacyMetrics.CloakedCookies++
+		}
 	}
 
+	// Check for purpose
+	if privacyMetrics.CookiesByPurpose == nil {
+		privacyMetrics.CookiesByPurpose = make(map[CookiePurpose]int)
+	}
+	privacyMetrics.CookiesByPurpose[cookie.Purpose]++
+
 	// Check for Security
 	if cookie.Secure {
 		privacyMetrics.TotalSecure++
 	} else {
 		privacyMetrics.TotalNotSecure++
 	}
+	if cookie.InsecureRequest != "" {
+		privacyMetrics.InsecureCookies++
+	}
 
 	// Check for Path
 	if cookie.Path != "/" {
@@ -422,6 +882,19 @@ func addToMetric(privacyMetrics *PrivacyMetric, cookie Cookie, url string) {
 		privacyMetrics.TotalPersistentCookies++
 	}
 
+	// Check how the cookie was set
+	switch cookie.Source {
+	case CookieSourceHTTP:
+		privacyMetrics.HTTPCookies++
+	case CookieSourceScript:
+		privacyMetrics.ScriptCookies++
+		if !isFirstParty(hostOf(cookie.SetBy), url) {
+			privacyMetrics.ThirdPartyScriptCookies++
+		}
+	default:
+		privacyMetrics.UnknownSourceCookies++
+	}
+
 }
 
 // Function: Print Privacy Metrics
@@ -430,14 +903,28 @@ func addToMetric(privacyMetrics *PrivacyMetric, cookie Cookie, url string) {
 func PrintMetrics(privacyMetrics PrivacyMetric, metricName string) {
 	fmt.Printf("#----- Printing %s Privacy Metrics ------#\n", metricName)
 
+	fmt.Printf("Crawl Outcome: %s\n", privacyMetrics.Outcome.Category)
+	fmt.Printf("Navigation Attempts: %d\n", privacyMetrics.Outcome.Attempts)
+
 	fmt.Printf("Total Cookies: %d\n", privacyMetrics.TotalCookies)
 
 	fmt.Printf("Total First-Party Cookies: %d\n", privacyMetrics.TotalFirstParty)
 	fmt.Printf("Total Third-Party Cookies: %d\n", privacyMetrics.TotalThirdParty)
+	fmt.Printf("Tracker Lists: %s\n", privacyMetrics.TrackerLists)
+	fmt.Printf("Total Tracker Cookies: %d\n", privacyMetrics.TotalTrackerCookies)
+	fmt.Printf("Total Non-Tracker Third-Party Cookies: %d\n", privacyMetrics.TotalNonTrackerThirdParty)
+	for _, category := range trackerCategories {
+		fmt.Printf("\t%s: %d\n", category, privacyMetrics.TrackerCookiesByCategory[category])
+	}
 
 	fmt.Printf("Total Secure Domains: %d\n", privacyMetrics.TotalSecure)
 	fmt.Printf("Total Unsecure Domains: %d\n", privacyMetrics.TotalNotSecure)
 
+	var transport strings.Builder
+	writeTLSInfo(&transport, privacyMetrics.TLS)
+	writeMixedContent(&transport, privacyMetrics)
+	fmt.Print(transport.String())
+
 	if len(privacyMetrics.SuspiciousPaths) > 0 {
 		fmt.Println("All Suspicious Paths")
 		for i := 0; i < len(privacyMetrics.SuspiciousPaths); i++ {
@@ -450,6 +937,7 @@ func PrintMetrics(privacyMetrics PrivacyMetric, metricName string) {
 			fmt.Printf("\tCookie %d. [%s]\n", i, partyType)
 			fmt.Printf("\t\tDomain: %s\n", cookie.Domain)
 			fmt.Printf("\t\tName: %s\n", cookie.Name)
+			fmt.Printf("\t\tVendor: %s\n", cookieLabel(cookie))
 			fmt.Printf("\t\tPath: %s\n", cookie.Path)
 		}
 	} else {
@@ -467,6 +955,77 @@ func PrintMetrics(privacyMetrics PrivacyMetric, metricName string) {
 	fmt.Printf("Total Session Cookies: %d\n", privacyMetrics.TotalSessionCookies)
 	fmt.Printf("Total Persistent Cookies: %d\n", privacyMetrics.TotalPersistentCookies)
 
+	var headers strings.Builder
+	writeHeaderAudit(&headers, privacyMetrics.Headers)
+	writeWellKnownFiles(&headers, privacyMetrics.WellKnown)
+	writeRedirects(&headers, privacyMetrics)
+	fmt.Print(headers.String())
+
+	fmt.Printf("Total HTTP Set-Cookie: %d\n", privacyMetrics.HTTPCookies)
+	fmt.Printf("Total Script Set Cookies: %d\n", privacyMetrics.ScriptCookies)
+	fmt.Printf("Total Third-Party Script Set Cookies: %d\n", privacyMetrics.ThirdPartyScriptCookies)
+	fmt.Printf("Total Unknown Source Cookies: %d\n", privacyMetrics.UnknownSourceCookies)
+
+	fmt.Printf("Cookie Catalog: %s\n", privacyMetrics.CookieCatalog)
+	fmt.Println("Cookies by Purpose:")
+	for _, purpose := range cookiePurposes {
+		fmt.Printf("\t%s: %d\n", purpose, privacyMetrics.CookiesByPurpose[purpose])
+	}
+
+	fmt.Printf("Cookie Sync Partners: %d\n", privacyMetrics.SyncPartners)
+	fmt.Printf("Cookie Sync Events: %d\n", len(privacyMetrics.CookieSyncs))
+	for _, sync := range privacyMetrics.CookieSyncs {
+		fmt.Printf("\t%s (%s) -> %s [%s]\n", sync.Cookie, sync.SourceDomain, sync.DestinationDomain, sync.Parameter)
+	}
+
+	fmt.Printf("CNAME Cloaked Hosts: %d\n", len(privacyMetrics.CloakedHosts))
+	for _, host := range privacyMetrics.CloakedHosts {
+		fmt.Printf("\t%s -> %s [%s]\n", host.Host, strings.Join(host.Chain, " -> "), host.Tracker)
+	}
+	fmt.Printf("CNAME Cloaked Cookies: %d\n", privacyMetrics.CloakedCookies)
+	fmt.Printf("CNAME Cloaked Requests: %d\n", privacyMetrics.CloakedRequests)
+
+	fmt.Printf("Fingerprinting Score: %d\n", privacyMetrics.FingerprintScore)
+	fmt.Printf("Fingerprinting API Calls: %d\n", privacyMetrics.FingerprintCalls)
+	fmt.Printf("Fingerprinting Scripts: %d\n", len(privacyMetrics.FingerprintingScripts))
+	for _, script := range privacyMetrics.FingerprintingScripts {
+		fmt.Printf("\t%s %v\n", script.URL, script.Categories)
+	}
+
+	fmt.Printf("Pages Visited: %d\n", privacyMetrics.PagesVisited)
+	fmt.Printf("Wait Strategy: %s\n", privacyMetrics.WaitMode)
+	fmt.Printf("Wait Time (ms): %d\n", privacyMetrics.WaitTime.Milliseconds())
+	fmt.Printf("Unsettled Waits: %d\n", privacyMetrics.UnsettledWaits)
+	fmt.Printf("Consent Mode: %s\n", privacyMetrics.Consent.Mode)
+	fmt.Printf("Consent Banner: %s\n", consentBanner(privacyMetrics.Consent))
+	fmt.Printf("Consent Clicked: %t\n", privacyMetrics.Consent.Clicked)
+	fmt.Printf("Privacy Signals (GPC/DNT): %t\n", privacyMetrics.PrivacySignals)
+	fmt.Printf("Total Requests: %d\n", privacyMetrics.TotalRequests)
+	fmt.Printf("Third-Party Requests: %d\n", privacyMetrics.ThirdPartyRequests)
+	fmt.Printf("Tracker Requests: %d\n", privacyMetrics.TrackerRequests)
+	fmt.Printf("Total Request Bytes: %d\n", privacyMetrics.TotalRequestBytes)
+	fmt.Printf("Third-Party Hosts: %d\n", len(privacyMetrics.ThirdPartyHosts))
+	for _, host := range privacyMetrics.ThirdPartyHosts {
+		fmt.Printf("\t%s [%s]: %d requests, %d bytes, %d failed\n", host.Host, trackerLabel(host.Tracker),
+			host.Requests, host.Bytes, host.Failed)
+	}
+	fmt.Printf("Storage Origins: %d\n", len(privacyMetrics.StorageOrigins))
+	fmt.Printf("Third-Party Storage Origins: %d\n", privacyMetrics.ThirdPartyStorageOrigins)
+	fmt.Printf("Total localStorage Keys: %d\n", privacyMetrics.LocalStorageKeys)
+	fmt.Printf("Total localStorage Size: %d\n", privacyMetrics.LocalStorageSize)
+	fmt.Printf("Total sessionStorage Keys: %d\n", privacyMetrics.SessionStorageKeys)
+	fmt.Printf("Total sessionStorage Size: %d\n", privacyMetrics.SessionStorageSize)
+	fmt.Printf("Total IndexedDB Databases: %d\n", privacyMetrics.IndexedDBDatabases)
+	for _, origin := range privacyMetrics.StorageOrigins {
+		partyType := "third-party"
+		if origin.IsFirstParty {
+			partyType = "first-party"
+		}
+		fmt.Printf("\t%s [%s]: %d local, %d session, %d IndexedDB\n", origin.Origin, partyType,
+			len(origin.LocalStorage), len(origin.SessionStorage), len(origin.IndexedDB))
+	}
+	fmt.Printf("Partial Crawl: %t\n", privacyMetrics.Partial)
+
 	fmt.Println("#--------------------------------------------#")
 }
 
@@ -493,7 +1052,30 @@ func PrintCookies(cookieList *CookiesList, url string, verbose *bool) {
 			}
 			fmt.Printf("\tCookie %d.[%s] \n", i+1, partyType)
 
-			fmt.Printf("\t\tFrom Page: %s\n", url)
+			fromPage := url
+			if cookie.FirstSeenPage != "" {
+				fromPage = cookie.FirstSeenPage
+			}
+			fmt.Printf("\t\tFrom Page: %s\n", fromPage)
+			fmt.Printf("\t\tFirst Seen: +%dms\n", cookie.FirstSeenAt.Milliseconds())
+			fmt.Printf("\t\tLast Changed: +%dms\n", cookie.LastChangedAt.Milliseconds())
+			if cookie.Tracker != "" {
+				fmt.Printf("\t\tTracker: %s\n", cookie.Tracker)
+			}
+			if cookie.CloakedBy != "" {
+				fmt.Printf("\t\tCNAME: %s\n", cookie.CloakedBy)
+			}
+			if cookie.InsecureRequest != "" {
+				fmt.Printf("\t\tSent Over HTTP: %s\n", cookie.InsecureRequest)
+			}
+			fmt.Printf("\t\tVendor: %s\n", cookieLabel(cookie))
+			if cookie.TypicalLifetime != "" {
+				fmt.Printf("\t\tTypical Lifetime: %s\n", cookie.TypicalLifetime)
+			}
+			fmt.Printf("\t\tSource: %s\n", cookie.Source)
+			if cookie.SetBy != "" {
+				fmt.Printf("\t\tSet By: %s\n", cookie.SetBy)
+			}
 			fmt.Printf("\t\tDomain: %s\n", cookie.Domain)
 			fmt.Printf("\t\tName: %s\n", cookie.Name)
 			fmt.Printf("\t\tValue: %s\n", cookie.Value)
@@ -523,7 +1105,8 @@ func PrintCookies(cookieList *CookiesList, url string, verbose *bool) {
 }
 
 // Function: isFirstParty
-// Operation: Check if the cookie domain is first-party or third-party
+// Operation: Check if the cookie domain or host is first-party or third-party, by
+// comparing its registrable domain (eTLD+1) with the one of the url
 // Return: True if first-party, false if third-party
 func isFirstParty(cookieDomain, fullURL string) bool {
 	parsedURL, err := url.Parse(fullURL)
@@ -532,15 +1115,12 @@ func isFirstParty(cookieDomain, fullURL string) bool {
 		return false
 	}
 
-	topLevelDomain := parsedURL.Host                           // Get the domain from the URL (e.g., "www.amazon.com")
-	cleanCookieDomain := strings.TrimPrefix(cookieDomain, ".") // TrimPrefix: removes "." from the start of cookieDomain
-
-	// HasPrefix: checks if the cleanCookieDomain STARTS with the topLevelDomain.
-	isPrefix := strings.HasPrefix(cleanCookieDomain, topLevelDomain)
-	// HasSuffix: checks if the topLevelDomain ENDS with the cleanCookieDomain.
-	isSuffix := strings.HasSuffix(topLevelDomain, cleanCookieDomain)
+	siteDomain := registrableDomain(parsedURL.Hostname()) // e.g. "www.amazon.co.uk" -> "amazon.co.uk"
+	if siteDomain == "" {
+		return false
+	}
 
-	return isSuffix || isPrefix
+	return registrableDomain(cookieDomain) == siteDomain
 }
 
 // Function: Analyze Metrics
@@ -558,6 +1138,21 @@ func AnalyzeMetrics(privacyMetric PrivacyMetric) map[string]float64 {
 		"sameSiteUnset":     0.0,
 		"sessionCookies":    0.0,
 		"persistentCookies": 0.0,
+		"fingerprinting":    float64(privacyMetric.FingerprintScore),
+		"headers":           float64(privacyMetric.Headers.Score),
+		"transportWeak":     float64(len(privacyMetric.TLS.Weaknesses)),
+		"bounceHosts":       float64(len(privacyMetric.BounceHosts)),
+		"mixedContent":      float64(len(privacyMetric.MixedContent)),
+		"insecureForms":     float64(len(privacyMetric.InsecureForms)),
+		"insecureCookies":   float64(privacyMetric.InsecureCookies),
+	}
+
+	// -1 marks headers and transport that were not inspected.
+	if len(privacyMetric.Headers.Findings) == 0 {
+		analysis["headers"] = -1
+	}
+	if privacyMetric.TLS.URL == "" {
+		analysis["transportWeak"] = -1
 	}
 
 	// --- Calculate Ratios of privacy metrics --- //
@@ -636,6 +1231,26 @@ func CreateReport(analysis map[string]float64) string {
 			analysis["secure"])
 	}
 
+	// ### TRANSPORT METRIC ###
+	// The Secure flag only helps if the connection itself is strong.
+	if analysis["transportWeak"] > 0 {
+		report += fmt.Sprintf("The connection to the website has %.0f transport weaknesses (see Transport "+
+			"Weaknesses), such as an old TLS version, a weak or invalid certificate or missing HSTS, "+
+			"which leaves room for man-in-the-middle attacks regardless of cookie flags. ",
+			analysis["transportWeak"])
+	} else if analysis["transportWeak"] == 0 {
+		report += "The connection to the website uses current TLS with a valid certificate and HSTS. "
+	}
+	if analysis["mixedContent"] > 0 || analysis["insecureForms"] > 0 {
+		report += fmt.Sprintf("Its pages reference %.0f subresources over plain HTTP and %.0f forms submit to HTTP "+
+			"(see Mixed Content and Insecure Form Targets), which can be read or altered on the network. ",
+			analysis["mixedContent"], analysis["insecureForms"])
+	}
+	if analysis["insecureCookies"] > 0 {
+		report += fmt.Sprintf("%.0f cookies were in scope of requests sent over HTTP and so travelled unencrypted. ",
+			analysis["insecureCookies"])
+	}
+
 	report += "\n\n"
 
 	// ### PARTY METRIC ###
@@ -735,6 +1350,44 @@ func CreateReport(analysis map[string]float64) string {
 	report += fmt.Sprintf("\t- Persistent Cookies: %.2f%%\n", analysis["persistentCookies"])
 	report += "\n"
 
+	// ### FINGERPRINT METRIC ###
+	if analysis["fingerprinting"] >= FINGERPRINT_THRESHOLD {
+		report += fmt.Sprintf("Scripts on the website read many browser and device properties (score %.0f of 100), "+
+			"which can identify the browser without any cookies and survives clearing them. ",
+			analysis["fingerprinting"])
+	} else if analysis["fingerprinting"] > 0 {
+		report += fmt.Sprintf("Some scripts on the website read browser and device properties (score %.0f of 100), "+
+			"which may be used to fingerprint the browser. ",
+			analysis["fingerprinting"])
+	} else {
+		report += "No fingerprinting scripts were detected. "
+	}
+	report += "\n"
+
+	// ### BOUNCE METRIC ###
+	if analysis["bounceHosts"] > 0 {
+		report += fmt.Sprintf("Navigations on the website were redirected through %.0f third-party hosts that set "+
+			"cookies on the way (see Bounce Tracking Hosts). Bounce tracking makes the tracker a first-party "+
+			"for a moment, so its cookies are not blocked as third-party cookies. ",
+			analysis["bounceHosts"])
+		report += "\n"
+	}
+
+	// ### HEADER METRIC ###
+	if analysis["headers"] >= HEADER_THRESHOLD {
+		report += fmt.Sprintf("The website sends strong security and privacy headers (score %.0f of 100), "+
+			"limiting script injection, framing and what is leaked to other sites in the Referer. ",
+			analysis["headers"])
+	} else if analysis["headers"] >= 0 {
+		report += fmt.Sprintf("The website is missing or weakens several security and privacy headers "+
+			"(score %.0f of 100), such as Content-Security-Policy, Strict-Transport-Security or Referrer-Policy, "+
+			"which leaves it more open to script injection, downgrade attacks and Referer leaks. ",
+			analysis["headers"])
+	} else {
+		report += "The response headers were not audited. "
+	}
+	report += "\n"
+
 	return report
 
 }
@@ -774,13 +1427,27 @@ func GetMetricsReport(privacyMetrics PrivacyMetric, metricName string) string {
 
 	report.WriteString(fmt.Sprintf("#----- Printing %s Privacy Metrics ------#\n", metricName))
 
+	report.WriteString(fmt.Sprintf("Crawl Outcome: %s\n", privacyMetrics.Outcome.Category))
+	report.WriteString(fmt.Sprintf("Navigation Attempts: %d\n", privacyMetrics.Outcome.Attempts))
+	if privacyMetrics.Outcome.Error != "" {
+		report.WriteString(fmt.Sprintf("Navigation Error: %s\n", privacyMetrics.Outcome.Error))
+	}
+
 	report.WriteString(fmt.Sprintf("Total Cookies: %d\n", privacyMetrics.TotalCookies))
 
 	report.WriteString(fmt.Sprintf("Total First-Party Cookies: %d\n", privacyMetrics.TotalFirstParty))
 	report.WriteString(fmt.Sprintf("Total Third-Party Cookies: %d\n", privacyMetrics.TotalThirdParty))
+	report.WriteString(fmt.Sprintf("Tracker Lists: %s\n", privacyMetrics.TrackerLists))
+	report.WriteString(fmt.Sprintf("Total Tracker Cookies: %d\n", privacyMetrics.TotalTrackerCookies))
+	report.WriteString(fmt.Sprintf("Total Non-Tracker Third-Party Cookies: %d\n", privacyMetrics.TotalNonTrackerThirdParty))
+	for _, category := range trackerCategories {
+		report.WriteString(fmt.Sprintf("\t%s: %d\n", category, privacyMetrics.TrackerCookiesByCategory[category]))
+	}
 
 	report.WriteString(fmt.Sprintf("Total Secure Domains: %d\n", privacyMetrics.TotalSecure))
 	report.WriteString(fmt.Sprintf("Total Unsecure Domains: %d\n", privacyMetrics.TotalNotSecure))
+	writeTLSInfo(&report, privacyMetrics.TLS)
+	writeMixedContent(&report, privacyMetrics)
 
 	if len(privacyMetrics.SuspiciousPaths) > 0 {
 		report.WriteString("All Suspicious Paths\n")
@@ -794,6 +1461,7 @@ func GetMetricsReport(privacyMetrics PrivacyMetric, metricName string) string {
 			report.WriteString(fmt.Sprintf("\tCookie %d. [%s]\n", i, partyType))
 			report.WriteString(fmt.Sprintf("\t\tDomain: %s\n", cookie.Domain))
 			report.WriteString(fmt.Sprintf("\t\tName: %s\n", cookie.Name))
+			report.WriteString(fmt.Sprintf("\t\tVendor: %s\n", cookieLabel(cookie)))
 			report.WriteString(fmt.Sprintf("\t\tPath: %s\n", cookie.Path))
 		}
 	} else {
@@ -811,6 +1479,95 @@ func GetMetricsReport(privacyMetrics PrivacyMetric, metricName string) string {
 	report.WriteString(fmt.Sprintf("Total Session Cookies: %d\n", privacyMetrics.TotalSessionCookies))
 	report.WriteString(fmt.Sprintf("Total Persistent Cookies: %d\n", privacyMetrics.TotalPersistentCookies))
 
+	writeHeaderAudit(&report, privacyMetrics.Headers)
+	writeWellKnownFiles(&report, privacyMetrics.WellKnown)
+	writeRedirects(&report, privacyMetrics)
+
+	report.WriteString(fmt.Sprintf("Total HTTP Set-Cookie: %d\n", privacyMetrics.HTTPCookies))
+	report.WriteString(fmt.Sprintf("Total Script Set Cookies: %d\n", privacyMetrics.ScriptCookies))
+	report.WriteString(fmt.Sprintf("Total Third-Party Script Set Cookies: %d\n", privacyMetrics.ThirdPartyScriptCookies))
+	report.WriteString(fmt.Sprintf("Total Unknown Source Cookies: %d\n", privacyMetrics.UnknownSourceCookies))
+
+	report.WriteString(fmt.Sprintf("Cookie Catalog: %s\n", privacyMetrics.CookieCatalog))
+	report.WriteString("Cookies by Purpose:\n")
+	for _, purpose := range cookiePurposes {
+		report.WriteString(fmt.Sprintf("\t%s: %d\n", purpose, privacyMetrics.CookiesByPurpose[purpose]))
+	}
+
+	report.WriteString(fmt.Sprintf("Cookie Sync Partners: %d\n", privacyMetrics.SyncPartners))
+	report.WriteString(fmt.Sprintf("Cookie Sync Events: %d\n", len(privacyMetrics.CookieSyncs)))
+	for _, sync := range privacyMetrics.CookieSyncs {
+		report.WriteString(fmt.Sprintf("\t%s (%s) -> %s [%s]\n", sync.Cookie, sync.SourceDomain, sync.DestinationDomain, sync.Parameter))
+	}
+
+	report.WriteString(fmt.Sprintf("CNAME Cloaked Hosts: %d\n", len(privacyMetrics.CloakedHosts)))
+	for _, host := range privacyMetrics.CloakedHosts {
+		report.WriteString(fmt.Sprintf("\t%s -> %s [%s]\n", host.Host, strings.Join(host.Chain, " -> "), host.Tracker))
+	}
+	report.WriteString(fmt.Sprintf("CNAME Cloaked Cookies: %d\n", privacyMetrics.CloakedCookies))
+	report.WriteString(fmt.Sprintf("CNAME Cloaked Requests: %d\n", privacyMetrics.CloakedRequests))
+
+	report.WriteString(fmt.Sprintf("Fingerprinting Score: %d\n", privacyMetrics.FingerprintScore))
+	report.WriteString(fmt.Sprintf("Fingerprinting API Calls: %d\n", privacyMetrics.FingerprintCalls))
+	report.WriteString(fmt.Sprintf("Fingerprinting Scripts: %d\n", len(privacyMetrics.FingerprintingScripts)))
+	for _, script := range privacyMetrics.FingerprintingScripts {
+		partyType := "third-party"
+		if script.IsFirstParty {
+			partyType = "first-party"
+		}
+		report.WriteString(fmt.Sprintf("\t%s [%s] %v\n", script.URL, partyType, script.Categories))
+		for _, api := range script.APIs {
+			report.WriteString(fmt.Sprintf("\t\t%s\n", api))
+		}
+	}
+
+	report.WriteString(fmt.Sprintf("Pages Visited: %d\n", privacyMetrics.PagesVisited))
+	report.WriteString(fmt.Sprintf("Wait Strategy: %s\n", privacyMetrics.WaitMode))
+	report.WriteString(fmt.Sprintf("Wait Time (ms): %d\n", privacyMetrics.WaitTime.Milliseconds()))
+	report.WriteString(fmt.Sprintf("Unsettled Waits: %d\n", privacyMetrics.UnsettledWaits))
+	report.WriteString(fmt.Sprintf("Consent Mode: %s\n", privacyMetrics.Consent.Mode))
+	report.WriteString(fmt.Sprintf("Consent Banner: %s\n", consentBanner(privacyMetrics.Consent)))
+	report.WriteString(fmt.Sprintf("Consent Clicked: %t\n", privacyMetrics.Consent.Clicked))
+	report.WriteString(fmt.Sprintf("Privacy Signals (GPC/DNT): %t\n", privacyMetrics.PrivacySignals))
+	if privacyMetrics.Consent.Error != "" {
+		report.WriteString(fmt.Sprintf("Consent Error: %s\n", privacyMetrics.Consent.Error))
+	}
+	report.WriteString(fmt.Sprintf("Total Requests: %d\n", privacyMetrics.TotalRequests))
+	report.WriteString(fmt.Sprintf("Third-Party Requests: %d\n", privacyMetrics.ThirdPartyRequests))
+	report.WriteString(fmt.Sprintf("Tracker Requests: %d\n", privacyMetrics.TrackerRequests))
+	report.WriteString(fmt.Sprintf("Total Request Bytes: %d\n", privacyMetrics.TotalRequestBytes))
+	report.WriteString(fmt.Sprintf("Third-Party Hosts: %d\n", len(privacyMetrics.ThirdPartyHosts)))
+	for _, host := range privacyMetrics.ThirdPartyHosts {
+		report.WriteString(fmt.Sprintf("\t%s [%s]: %d requests, %d bytes, %d failed\n", host.Host, trackerLabel(host.Tracker),
+			host.Requests, host.Bytes, host.Failed))
+	}
+	report.WriteString(fmt.Sprintf("Storage Origins: %d\n", len(privacyMetrics.StorageOrigins)))
+	report.WriteString(fmt.Sprintf("Third-Party Storage Origins: %d\n", privacyMetrics.ThirdPartyStorageOrigins))
+	report.WriteString(fmt.Sprintf("Total localStorage Keys: %d\n", privacyMetrics.LocalStorageKeys))
+	report.WriteString(fmt.Sprintf("Total localStorage Size: %d\n", privacyMetrics.LocalStorageSize))
+	report.WriteString(fmt.Sprintf("Total sessionStorage Keys: %d\n", privacyMetrics.SessionStorageKeys))
+	report.WriteString(fmt.Sprintf("Total sessionStorage Size: %d\n", privacyMetrics.SessionStorageSize))
+	report.WriteString(fmt.Sprintf("Total IndexedDB Databases: %d\n", privacyMetrics.IndexedDBDatabases))
+	for _, origin := range privacyMetrics.StorageOrigins {
+		partyType := "third-party"
+		if origin.IsFirstParty {
+			partyType = "first-party"
+		}
+		report.WriteString(fmt.Sprintf("\t%s [%s]: %d local, %d session, %d IndexedDB\n", origin.Origin, partyType,
+			len(origin.LocalStorage), len(origin.SessionStorage), len(origin.IndexedDB)))
+		for _, item := range origin.LocalStorage {
+			report.WriteString(fmt.Sprintf("\t\tlocal %s (%d)\n", item.Key, item.Size))
+		}
+		for _, item := range origin.SessionStorage {
+			report.WriteString(fmt.Sprintf("\t\tsession %s (%d)\n", item.Key, item.Size))
+		}
+		for _, database := range origin.IndexedDB {
+			report.WriteString(fmt.Sprintf("\t\tIndexedDB %s v%d: %s\n", database.Name, database.Version,
+				strings.Join(database.Stores, ", ")))
+		}
+	}
+	report.WriteString(fmt.Sprintf("Partial Crawl: %t\n", privacyMetrics.Partial))
+
 	report.WriteString("#--------------------------------------------#\n")
 
 	return report.String()
@@ -820,33 +1577,139 @@ func GetMetricsReport(privacyMetrics PrivacyMetric, metricName string) string {
 // Operation: Runs the complete privacy crawl process for a single URL
 // Return: error if any step fails
 func RunPrivacyCrawl(browser string, isHidden bool, url string, duration int, verbose bool) error {
+	_, err := RunPrivacyCrawlWithOptions(context.Background(), CrawlOptions{
+		Browser:  browser,
+		Hidden:   isHidden,
+		URL:      url,
+		Duration: duration,
+		Verbose:  verbose,
+	})
+	return err
+}
+
+// Function: RunPrivacyCrawlWithOptions
+// Operation: Same as RunPrivacyCrawl, but takes its settings from CrawlOptions.
+// A cancelled crawl still saves what it collected, marked as partial.
+// Return: *CookiesList of the baseline crawl (nil if none ran), and an error if
+// any step fails, or ctx.Err() if the crawl was cancelled
+func RunPrivacyCrawlWithOptions(ctx context.Context, options CrawlOptions) (*CookiesList, error) {
+	browser := options.Browser
+	url := options.URL
+	verbose := options.Verbose
+
 	// Get available browsers and verify the selected one
 	browserList := GetBrowsers(&verbose)
-	_, _, err := VerifyTargetBrowser(browserList, browser, &verbose)
+	_, userAgent, err := VerifyTargetBrowser(browserList, browser, &verbose)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Client for the requests made outside the browser, shared by the comparisons.
+	if options.HTTPClient == nil {
+		options.HTTPClient, err = ReadHTTPClient(HTTPCLIENTFILE)
+		if err != nil {
+			fmt.Printf("%v, using default HTTP client\n", err)
+		}
 	}
 
 	// Declare structure for privacy metrics
 	privacyMetric := PrivacyMetric{}
 
-	// Fetch cookies
-	cookies := FetchCookies(browser, isHidden, url, &privacyMetric, &verbose, duration)
+	// Fetch cookies, once per consent mode when comparing
+	var cookies *CookiesList
+	if options.Consent == ConsentCompare {
+		comparison := CompareConsentModes(ctx, options)
+		consentReport := CreateConsentReport(comparison)
+		if options.PrintReports {
+			fmt.Println(consentReport)
+		}
+
+		err = AppendConsentToFile(consentReport, url, browser)
+		if err != nil {
+			return nil, err
+		}
+
+		// The no-action crawl is the baseline written to DATA.txt.
+		cookies = comparison.Cookies[ConsentNoAction]
+		privacyMetric = comparison.Metrics[ConsentNoAction]
+		if cookies == nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	// Crawl without and with privacy signals, the crawl without is the baseline
+	// unless the consent comparison already gave one
+	if options.GPCCompare && ctx.Err() == nil {
+		comparison := CompareGPC(ctx, options)
+		gpcReport := CreateGPCReport(comparison)
+		if options.PrintReports {
+			fmt.Println(gpcReport)
+		}
+
+		err = AppendGPCToFile(gpcReport, url, browser)
+		if err != nil {
+			return cookies, err
+		}
+
+		if cookies == nil {
+			cookies = comparison.Baseline
+			privacyMetric = comparison.BaselineMetrics
+		}
+	}
+
 	if cookies == nil {
-		return fmt.Errorf("failed to fetch cookies")
+		cookies = FetchCookiesWithOptions(ctx, options, &privacyMetric)
 	}
 
-	// Generate metrics report
+	// Audit the response headers, published files, TLS connection and redirects of the site
+	if ctx.Err() == nil {
+		preload := options.HSTSPreload
+		if preload == nil {
+			preload, err = ReadHSTSPreloadList(HSTSPRELOADFILE)
+			if err != nil {
+				fmt.Printf("%v, HSTS preloading will not be checked\n", err)
+			}
+		}
+
+		// Headers, TLS and redirects come from one fetch of the landing page.
+		landing := FetchLandingPage(ctx, options.HTTPClient, url, url, userAgent, preload, &verbose)
+		privacyMetric.Headers = landing.Headers
+		privacyMetric.TLS = landing.TLS
+		privacyMetric.HTTPRedirects = landing.Redirects
+		privacyMetric.WellKnown = FetchWellKnownFiles(ctx, options.HTTPClient, url, userAgent, &verbose)
+	}
+
+	// Generate metrics report, failed crawls included so their outcome is recorded
 	urlTarget := url + ": Cookies"
 	data := GetMetricsReport(privacyMetric, urlTarget)
+	if options.PrintReports {
+		fmt.Println(data)
+	}
 
 	// Append data to file
 	err = AppendDataToFile(data, url, browser)
 	if err != nil {
-		return err
+		return cookies, err
 	}
 
-	return nil
+	err = AppendTimelineToFile(cookies.Timeline, url, browser)
+	if err != nil {
+		return cookies, err
+	}
+
+	err = AppendNetworkToFile(cookies.Requests, url, browser)
+	if err != nil {
+		return cookies, err
+	}
+
+	if cookies.Partial {
+		return cookies, ctx.Err()
+	}
+	if cookies.Outcome.IsFailure() {
+		return cookies, fmt.Errorf("failed to fetch cookies: [%s] %s", cookies.Outcome.Category, cookies.Outcome.Error)
+	}
+
+	return cookies, nil
 }
 
 // Make function to import packet data into struct.
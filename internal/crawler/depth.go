@@ -0,0 +1,105 @@
+package crawler
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Page Cookies: Represents one page of a multi-page visit and the cookies
+// present in the context after it loaded.
+type PageCookies struct {
+	URL        string
+	Depth      int
+	Cookies    []Cookie
+	NewCookies int // Cookies first seen on this page.
+	Wait       WaitResult
+}
+
+// Page Target: A page waiting to be visited.
+type pageTarget struct {
+	url   string
+	depth int
+}
+
+// ---- Global Definitions ---- //
+
+// Page budget used when a depth is set without MaxPages.
+const DEFAULT_MAX_PAGES int = 10
+
+// Collects the absolute href of every link on the page.
+const linksScript string = `() => Array.from(document.querySelectorAll('a[href]'), a => a.href)`
+
+// ---- Functions ---- //
+
+// Function: Page Budget
+// Operation: Returns how many pages the crawl may visit.
+// Return: int
+func (options CrawlOptions) pageBudget() int {
+	if options.Depth <= 0 {
+		return 1
+	}
+	if options.MaxPages > 0 {
+		return options.MaxPages
+	}
+	return DEFAULT_MAX_PAGES
+}
+
+// Function: Cookie Key
+// Operation: Identifies a cookie the same way the browser does, by name, domain and path.
+// Return: String
+func cookieKey(cookie Cookie) string {
+	return cookie.Name + "|" + cookie.Domain + "|" + cookie.Path
+}
+
+// Function: Normalize Link
+// Operation: Drops the fragment and trailing slash so the same page is only queued once.
+// Return: String
+func normalizeLink(link string) string {
+	parsedURL, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	parsedURL.Fragment = ""
+
+	return strings.TrimSuffix(parsedURL.String(), "/")
+}
+
+// Function: Extract Same-Site Links
+// Operation: Returns the http(s) links on the page that stay on the site of siteURL.
+// Return: []string
+func extractSameSiteLinks(page playwright.Page, siteURL string) []string {
+	result, err := page.Evaluate(linksScript)
+	if err != nil {
+		fmt.Printf("could not read links: %v\n", err)
+		return nil
+	}
+
+	hrefs, ok := result.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var links []string
+	for _, href := range hrefs {
+		link, ok := href.(string)
+		if !ok {
+			continue
+		}
+
+		parsedURL, err := url.Parse(link)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+			continue
+		}
+
+		if isFirstParty(parsedURL.Hostname(), siteURL) {
+			links = append(links, link)
+		}
+	}
+
+	return links
+}
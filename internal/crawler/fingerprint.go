@@ -0,0 +1,288 @@
+package crawler
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Fingerprint Category: Represents a group of browser APIs used for fingerprinting.
+type FingerprintCategory string
+
+const (
+	FingerprintCanvas    FingerprintCategory = "canvas"    // Canvas readback.
+	FingerprintWebGL     FingerprintCategory = "webgl"     // WebGL parameters and readback.
+	FingerprintAudio     FingerprintCategory = "audio"     // AudioContext processing.
+	FingerprintFonts     FingerprintCategory = "fonts"     // Font enumeration and text metrics of many fonts.
+	FingerprintNavigator FingerprintCategory = "navigator" // Uncommon navigator and screen hardware properties.
+	FingerprintDevices   FingerprintCategory = "devices"   // Battery and media device APIs.
+)
+
+// Fingerprint Call: Represents one API a script called, recorded once per API and script.
+type FingerprintCall struct {
+	Category FingerprintCategory
+	API      string
+	Script   string // URL of the calling script, the document URL for inline scripts.
+}
+
+// Fingerprint Script: Represents a script that called fingerprinting APIs.
+type FingerprintScript struct {
+	URL          string
+	IsFirstParty bool
+	Categories   []FingerprintCategory
+	APIs         []string
+}
+
+// Fingerprint Recorder: Collects the fingerprinting API calls of every frame.
+type fingerprintRecorder struct {
+	mu    sync.Mutex
+	calls []FingerprintCall
+	seen  map[FingerprintCall]bool
+}
+
+// ---- Global Definitions ---- //
+
+// Name of the binding the init script reports API calls to.
+const fingerprintBinding string = "__privcrawlerFingerprint"
+
+// A script fingerprints when it uses APIs from at least this many categories,
+// one of them high-entropy.
+const FINGERPRINT_MIN_CATEGORIES int = 2
+
+// Text metrics and font checks count as font enumeration once a script has
+// used this many distinct fonts; pages lay out text in only a few.
+const FINGERPRINT_MIN_FONTS int = 20
+
+// Categories that read rendering or audio output, which differ between devices
+// far more than the properties pages commonly read for feature detection.
+var highEntropyCategories = map[FingerprintCategory]bool{
+	FingerprintCanvas: true,
+	FingerprintWebGL:  true,
+	FingerprintAudio:  true,
+}
+
+// Fingerprint Weights: What each category adds to the fingerprinting score,
+// out of 100, when a fingerprinting script uses it.
+var fingerprintWeights = map[FingerprintCategory]int{
+	FingerprintCanvas:    25,
+	FingerprintWebGL:     20,
+	FingerprintAudio:     20,
+	FingerprintFonts:     15,
+	FingerprintNavigator: 10,
+	FingerprintDevices:   10,
+}
+
+// Wraps the fingerprinting APIs in every frame and reports each API once per
+// calling script, with the script URL taken from the call stack. Font APIs are
+// only reported once a script has used FINGERPRINT_MIN_FONTS distinct fonts,
+// which newFingerprintRecorder fills in. Navigator properties read for feature
+// detection (platform, languages, maxTouchPoints) are not wrapped.
+const fingerprintScript string = `(() => {
+	const minFonts = FINGERPRINT_MIN_FONTS;
+	const reported = new Set();
+	const fontsByScript = new Map();
+	const scriptURL = () => {
+		const stack = new Error().stack || '';
+		const match = stack.match(/(https?:\/\/[^\s()@]+?):\d+:\d+/);
+		return match ? match[1] : location.href;
+	};
+	const report = (category, api) => {
+		const script = scriptURL();
+		const key = category + '|' + api + '|' + script;
+		if (reported.has(key)) return;
+		reported.add(key);
+		try { window.` + fingerprintBinding + `(category, api, script); } catch (e) {}
+	};
+
+	const wrapMethod = (category, owner, name, label) => {
+		if (!owner || !owner.prototype || typeof owner.prototype[name] !== 'function') return;
+		const original = owner.prototype[name];
+		owner.prototype[name] = function () {
+			report(category, label + '.' + name);
+			return original.apply(this, arguments);
+		};
+	};
+	const wrapGetter = (category, owner, name, label) => {
+		if (!owner || !owner.prototype) return;
+		const descriptor = Object.getOwnPropertyDescriptor(owner.prototype, name);
+		if (!descriptor || !descriptor.get) return;
+		Object.defineProperty(owner.prototype, name, {
+			configurable: true,
+			enumerable: descriptor.enumerable,
+			get: function () {
+				report(category, label + '.' + name);
+				return descriptor.get.call(this);
+			},
+		});
+	};
+
+	const wrapFontProbe = (owner, name, label, fontOf) => {
+		if (!owner || !owner.prototype || typeof owner.prototype[name] !== 'function') return;
+		const original = owner.prototype[name];
+		owner.prototype[name] = function () {
+			const script = scriptURL();
+			const fonts = fontsByScript.get(script) || new Set();
+			fontsByScript.set(script, fonts);
+			if (fonts.size < minFonts) {
+				fonts.add(String(fontOf(this, arguments)));
+				if (fonts.size >= minFonts) report('fonts', label + '.' + name);
+			}
+			return original.apply(this, arguments);
+		};
+	};
+
+	['toDataURL', 'toBlob'].forEach((name) => wrapMethod('canvas', window.HTMLCanvasElement, name, 'HTMLCanvasElement'));
+	['getImageData', 'isPointInPath'].forEach((name) => wrapMethod('canvas', window.CanvasRenderingContext2D, name, 'CanvasRenderingContext2D'));
+	['convertToBlob'].forEach((name) => wrapMethod('canvas', window.OffscreenCanvas, name, 'OffscreenCanvas'));
+
+	['getParameter', 'getSupportedExtensions', 'getExtension', 'readPixels', 'getShaderPrecisionFormat'].forEach((name) => {
+		wrapMethod('webgl', window.WebGLRenderingContext, name, 'WebGLRenderingContext');
+		wrapMethod('webgl', window.WebGL2RenderingContext, name, 'WebGL2RenderingContext');
+	});
+
+	['createOscillator', 'createDynamicsCompressor', 'createAnalyser'].forEach((name) => wrapMethod('audio', window.BaseAudioContext || window.AudioContext, name, 'AudioContext'));
+	wrapMethod('audio', window.OfflineAudioContext, 'startRendering', 'OfflineAudioContext');
+	wrapMethod('audio', window.AnalyserNode, 'getFloatFrequencyData', 'AnalyserNode');
+
+	wrapFontProbe(window.CanvasRenderingContext2D, 'measureText', 'CanvasRenderingContext2D', (context) => context.font);
+	wrapFontProbe(window.FontFaceSet, 'check', 'FontFaceSet', (set, args) => args[0]);
+	wrapMethod('fonts', window.Window, 'queryLocalFonts', 'window');
+
+	['hardwareConcurrency', 'deviceMemory', 'plugins', 'mimeTypes', 'oscpu', 'cpuClass']
+		.forEach((name) => wrapGetter('navigator', window.Navigator, name, 'navigator'));
+	['colorDepth', 'pixelDepth', 'availWidth', 'availHeight'].forEach((name) => wrapGetter('navigator', window.Screen, name, 'screen'));
+
+	wrapMethod('devices', window.Navigator, 'getBattery', 'navigator');
+	wrapMethod('devices', window.MediaDevices, 'enumerateDevices', 'MediaDevices');
+})();`
+
+// ---- Functions ---- //
+
+// Function: New Fingerprint Recorder
+// Operation: Exposes the fingerprint binding and installs the instrumentation
+// script in the browser context. Must be called before any page is opened.
+// Return: *fingerprintRecorder, Error
+func newFingerprintRecorder(browserContext playwright.BrowserContext) (*fingerprintRecorder, error) {
+	recorder := &fingerprintRecorder{seen: make(map[FingerprintCall]bool)}
+
+	err := browserContext.ExposeBinding(fingerprintBinding, func(source *playwright.BindingSource, args ...interface{}) interface{} {
+		if len(args) < 3 {
+			return nil
+		}
+		category, _ := args[0].(string)
+		api, _ := args[1].(string)
+		script, _ := args[2].(string)
+
+		call := FingerprintCall{Category: FingerprintCategory(category), API: api, Script: script}
+
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+		if !recorder.seen[call] {
+			recorder.seen[call] = true
+			recorder.calls = append(recorder.calls, call)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not expose fingerprint binding: %v", err)
+	}
+
+	script := strings.Replace(fingerprintScript, "FINGERPRINT_MIN_FONTS", strconv.Itoa(FINGERPRINT_MIN_FONTS), 1)
+	err = browserContext.AddInitScript(playwright.Script{Content: playwright.String(script)})
+	if err != nil {
+		return nil, fmt.Errorf("could not add fingerprint script: %v", err)
+	}
+
+	return recorder, nil
+}
+
+// Function: List
+// Operation: Returns the distinct API calls recorded so far.
+// Return: []FingerprintCall
+func (r *fingerprintRecorder) list() []FingerprintCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]FingerprintCall(nil), r.calls...)
+}
+
+// Function: Fingerprinting Scripts
+// Operation: Groups the calls by script and keeps the scripts that use at least
+// FINGERPRINT_MIN_CATEGORIES categories including a high-entropy one, sorted by URL.
+// Return: []FingerprintScript
+func fingerprintingScripts(calls []FingerprintCall, siteURL string) []FingerprintScript {
+	byScript := make(map[string]*FingerprintScript)
+	categories := make(map[string]map[FingerprintCategory]bool)
+
+	for _, call := range calls {
+		script, ok := byScript[call.Script]
+		if !ok {
+			script = &FingerprintScript{URL: call.Script, IsFirstParty: isFirstParty(hostOf(call.Script), siteURL)}
+			byScript[call.Script] = script
+			categories[call.Script] = make(map[FingerprintCategory]bool)
+		}
+		script.APIs = append(script.APIs, call.API)
+		if !categories[call.Script][call.Category] {
+			categories[call.Script][call.Category] = true
+			script.Categories = append(script.Categories, call.Category)
+		}
+	}
+
+	var scripts []FingerprintScript
+	for _, script := range byScript {
+		if len(script.Categories) >= FINGERPRINT_MIN_CATEGORIES && usesHighEntropy(script.Categories) {
+			sort.Strings(script.APIs)
+			scripts = append(scripts, *script)
+		}
+	}
+	sort.Slice(scripts, func(i, j int) bool {
+		return scripts[i].URL < scripts[j].URL
+	})
+
+	return scripts
+}
+
+// Function: Uses High Entropy
+// Operation: Reports whether any of the categories is high-entropy.
+// Return: bool
+func usesHighEntropy(categories []FingerprintCategory) bool {
+	for _, category := range categories {
+		if highEntropyCategories[category] {
+			return true
+		}
+	}
+	return false
+}
+
+// Function: Fingerprint Score
+// Operation: Adds up the weight of every category used by a fingerprinting script.
+// Return: int, from 0 to 100
+func fingerprintScore(scripts []FingerprintScript) int {
+	used := make(map[FingerprintCategory]bool)
+	for _, script := range scripts {
+		for _, category := range script.Categories {
+			used[category] = true
+		}
+	}
+
+	score := 0
+	for category := range used {
+		score += fingerprintWeights[category]
+	}
+	return score
+}
+
+// Function: Add Fingerprinting To Metric
+// Operation: Sets the fingerprinting score and scripts of the visit.
+// Return: None
+func addFingerprintingToMetric(privacyMetrics *PrivacyMetric, calls []FingerprintCall, url string) {
+	privacyMetrics.FingerprintCalls = len(calls)
+	privacyMetrics.FingerprintingScripts = fingerprintingScripts(calls, url)
+	privacyMetrics.FingerprintScore = fingerprintScore(privacyMetrics.FingerprintingScripts)
+}
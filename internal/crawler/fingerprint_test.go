@@ -0,0 +1,124 @@
+package crawler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFingerprintingScripts(t *testing.T) {
+	const site = "https://www.example.com"
+	const first = "https://static.example.com/app.js"
+	const third = "https://cdn.fp-vendor.net/fp.js"
+	const other = "https://widgets.other.org/w.js"
+
+	tests := []struct {
+		name    string
+		calls   []FingerprintCall
+		scripts []FingerprintScript
+		score   int
+	}{
+		{
+			name:  "no calls",
+			score: 0,
+		},
+		{
+			name: "below the category minimum",
+			calls: []FingerprintCall{
+				{FingerprintCanvas, "HTMLCanvasElement.toDataURL", third},
+				{FingerprintCanvas, "CanvasRenderingContext2D.getImageData", third},
+			},
+			score: 0,
+		},
+		{
+			name: "enough categories but none high-entropy",
+			calls: []FingerprintCall{
+				{FingerprintNavigator, "Navigator.hardwareConcurrency", third},
+				{FingerprintDevices, "Navigator.getBattery", third},
+				{FingerprintFonts, "CanvasRenderingContext2D.measureText", third},
+			},
+			score: 0,
+		},
+		{
+			name: "first- and third-party scripts",
+			calls: []FingerprintCall{
+				{FingerprintWebGL, "WebGLRenderingContext.getParameter", third},
+				{FingerprintCanvas, "HTMLCanvasElement.toDataURL", first},
+				{FingerprintNavigator, "Navigator.hardwareConcurrency", third},
+				{FingerprintFonts, "FontFaceSet.check", first},
+				{FingerprintWebGL, "WebGLRenderingContext.readPixels", third},
+			},
+			scripts: []FingerprintScript{
+				{URL: third, IsFirstParty: false, Categories: []FingerprintCategory{FingerprintWebGL, FingerprintNavigator},
+					APIs: []string{"Navigator.hardwareConcurrency", "WebGLRenderingContext.getParameter", "WebGLRenderingContext.readPixels"}},
+				{URL: first, IsFirstParty: true, Categories: []FingerprintCategory{FingerprintCanvas, FingerprintFonts},
+					APIs: []string{"FontFaceSet.check", "HTMLCanvasElement.toDataURL"}},
+			},
+			score: 20 + 10 + 25 + 15,
+		},
+		{
+			name: "categories used by several scripts count once",
+			calls: []FingerprintCall{
+				{FingerprintCanvas, "HTMLCanvasElement.toDataURL", third},
+				{FingerprintAudio, "AudioContext.createOscillator", third},
+				{FingerprintCanvas, "HTMLCanvasElement.toDataURL", other},
+				{FingerprintAudio, "OfflineAudioContext.startRendering", other},
+				{FingerprintDevices, "MediaDevices.enumerateDevices", other},
+				{FingerprintDevices, "Navigator.getBattery", first}, // Alone, not fingerprinting.
+			},
+			scripts: []FingerprintScript{
+				{URL: third, Categories: []FingerprintCategory{FingerprintCanvas, FingerprintAudio},
+					APIs: []string{"AudioContext.createOscillator", "HTMLCanvasElement.toDataURL"}},
+				{URL: other, Categories: []FingerprintCategory{FingerprintCanvas, FingerprintAudio, FingerprintDevices},
+					APIs: []string{"HTMLCanvasElement.toDataURL", "MediaDevices.enumerateDevices", "OfflineAudioContext.startRendering"}},
+			},
+			score: 25 + 20 + 10,
+		},
+		{
+			name: "every category",
+			calls: []FingerprintCall{
+				{FingerprintCanvas, "HTMLCanvasElement.toDataURL", third},
+				{FingerprintWebGL, "WebGLRenderingContext.getParameter", third},
+				{FingerprintAudio, "AudioContext.createOscillator", third},
+				{FingerprintFonts, "FontFaceSet.check", third},
+				{FingerprintNavigator, "Navigator.deviceMemory", third},
+				{FingerprintDevices, "Navigator.getBattery", third},
+			},
+			scripts: []FingerprintScript{
+				{URL: third, Categories: []FingerprintCategory{FingerprintCanvas, FingerprintWebGL, FingerprintAudio,
+					FingerprintFonts, FingerprintNavigator, FingerprintDevices},
+					APIs: []string{"AudioContext.createOscillator", "FontFaceSet.check", "HTMLCanvasElement.toDataURL",
+						"Navigator.deviceMemory", "Navigator.getBattery", "WebGLRenderingContext.getParameter"}},
+			},
+			score: 100,
+		},
+	}
+
+	for _, test := range tests {
+		scripts := fingerprintingScripts(test.calls, site)
+		if !reflect.DeepEqual(scripts, test.scripts) {
+			t.Errorf("%s: scripts %+v, want %+v", test.name, scripts, test.scripts)
+		}
+		if score := fingerprintScore(scripts); score != test.score {
+			t.Errorf("%s: score %d, want %d", test.name, score, test.score)
+		}
+	}
+}
+
+func TestUsesHighEntropy(t *testing.T) {
+	tests := []struct {
+		categories []FingerprintCategory
+		want       bool
+	}{
+		{nil, false},
+		{[]FingerprintCategory{FingerprintFonts, FingerprintNavigator, FingerprintDevices}, false},
+		{[]FingerprintCategory{FingerprintNavigator, FingerprintCanvas}, true},
+		{[]FingerprintCategory{FingerprintWebGL}, true},
+		{[]FingerprintCategory{FingerprintDevices, FingerprintAudio}, true},
+	}
+
+	for _, test := range tests {
+		if got := usesHighEntropy(test.categories); got != test.want {
+			t.Errorf("usesHighEntropy(%v) = %t, want %t", test.categories, got, test.want)
+		}
+	}
+}
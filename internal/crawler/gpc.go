@@ -0,0 +1,349 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// GPC Support: Represents what the site declares in /.well-known/gpc.json.
+// Found is false, and Error set, if the file is missing or not valid JSON.
+type GPCSupport struct {
+	URL        string
+	Status     int
+	Found      bool
+	GPC        bool
+	LastUpdate string
+	Error      string
+}
+
+// GPC Comparison: Represents one site crawled without and with privacy signals.
+type GPCComparison struct {
+	URL     string
+	Browser string
+
+	// Crawl without signals, and the crawl sending Sec-GPC and DNT.
+	Baseline        *CookiesList
+	BaselineMetrics PrivacyMetric
+	Signals         *CookiesList
+	SignalsMetrics  PrivacyMetric
+
+	Support GPCSupport
+}
+
+// ---- Global Definitions ---- //
+
+// GPC Report File: Location of the GPC comparison reports.
+const GPCREPORTFILE string = "GPC.txt"
+
+// Path of the GPC support resource, relative to the site origin.
+const GPC_WELL_KNOWN_PATH string = "/.well-known/gpc.json"
+
+// Request headers of the privacy signals.
+var privacySignalHeaders = map[string]string{
+	"Sec-GPC": "1",
+	"DNT":     "1",
+}
+
+// privacySignalScript: Sets the script-visible privacy signals in every frame,
+// before page scripts run.
+const privacySignalScript = `(() => {
+  const define = (name, value) => {
+    try {
+      Object.defineProperty(Navigator.prototype, name, { get: () => value, configurable: true });
+    } catch (e) {}
+  };
+  define('globalPrivacyControl', true);
+  define('doNotTrack', '1');
+})();`
+
+// ---- Functions ---- //
+
+// Function: Send Privacy Signals
+// Operation: Makes every request of the context send Sec-GPC: 1 and DNT: 1, and
+// sets navigator.globalPrivacyControl and navigator.doNotTrack in every frame.
+// Return: Error
+func sendPrivacySignals(browserContext playwright.BrowserContext) error {
+	err := browserContext.SetExtraHTTPHeaders(privacySignalHeaders)
+	if err != nil {
+		return fmt.Errorf("could not set privacy signal headers: %v", err)
+	}
+
+	err = browserContext.AddInitScript(playwright.Script{Content: playwright.String(privacySignalScript)})
+	if err != nil {
+		return fmt.Errorf("could not set privacy signals: %v", err)
+	}
+	return nil
+}
+
+// Function: Fetch GPC Support
+// Operation: Fetches /.well-known/gpc.json from the origin of the site and
+// reads the declared support.
+// Return: GPCSupport
+func FetchGPCSupport(ctx context.Context, client *HTTPClient, siteURL, userAgent string, verbose *bool) GPCSupport {
+	origin, err := siteOrigin(siteURL)
+	if err != nil {
+		return GPCSupport{Error: err.Error()}
+	}
+
+	support := GPCSupport{URL: origin + GPC_WELL_KNOWN_PATH}
+
+	body, status, err := fetchWellKnown(ctx, client, support.URL, userAgent, verbose)
+	support.Status = status
+	if err != nil {
+		support.Error = err.Error()
+		return support
+	}
+
+	var declared struct {
+		GPC        bool   `json:"gpc"`
+		LastUpdate string `json:"lastUpdate"`
+	}
+	err = json.Unmarshal(body, &declared)
+	if err != nil {
+		support.Error = fmt.Sprintf("error parsing gpc.json: %v", err)
+		return support
+	}
+
+	support.Found = true
+	support.GPC = declared.GPC
+	support.LastUpdate = declared.LastUpdate
+	return support
+}
+
+// Function: Write GPC Support
+// Operation: Writes the declared GPC support for reports.
+// Return: None
+func writeGPCSupport(report *strings.Builder, support GPCSupport) {
+	if !support.Found {
+		writeNotFound(report, "gpc.json", support.Error)
+		return
+	}
+	report.WriteString(fmt.Sprintf("gpc.json: gpc %t, last update %s\n", support.GPC, support.LastUpdate))
+}
+
+// Function: Compare GPC
+// Operation: Crawls the site twice in fresh contexts, without and then with the
+// privacy signals, and fetches its declared GPC support. A consent comparison is
+// not repeated here; both crawls leave the banner alone.
+// Return: *GPCComparison
+func CompareGPC(ctx context.Context, options CrawlOptions) *GPCComparison {
+	comparison := &GPCComparison{
+		URL:     options.URL,
+		Browser: options.Browser,
+	}
+
+	if options.Consent == ConsentCompare {
+		options.Consent = ConsentNoAction
+	}
+
+	baselineOptions := options
+	baselineOptions.PrivacySignals = false
+	comparison.Baseline = FetchCookiesWithOptions(ctx, baselineOptions, &comparison.BaselineMetrics)
+
+	if ctx.Err() == nil {
+		signalOptions := options
+		signalOptions.PrivacySignals = true
+		comparison.Signals = FetchCookiesWithOptions(ctx, signalOptions, &comparison.SignalsMetrics)
+	}
+
+	if ctx.Err() == nil {
+		quiet := false
+		userAgent := GetBrowsers(&quiet)[options.Browser]
+		comparison.Support = FetchGPCSupport(ctx, options.HTTPClient, options.URL, userAgent, &options.Verbose)
+	}
+
+	return comparison
+}
+
+// Function: Create GPC Report
+// Operation: Summarizes both crawls, the cookies, storage keys and third-party
+// hosts that differ between them, and whether the declared support matches.
+// Return: A string which contains the report
+func CreateGPCReport(comparison *GPCComparison) string {
+	var report strings.Builder
+
+	report.WriteString(fmt.Sprintf("#----- Privacy Signals for %s ------#\n", comparison.URL))
+
+	writeGPCSupport(&report, comparison.Support)
+
+	crawls := []struct {
+		name    string
+		cookies *CookiesList
+		metric  PrivacyMetric
+	}{
+		{"without signals", comparison.Baseline, comparison.BaselineMetrics},
+		{"with signals", comparison.Signals, comparison.SignalsMetrics},
+	}
+	for _, crawl := range crawls {
+		if crawl.cookies == nil {
+			report.WriteString(fmt.Sprintf("%s: not crawled\n", crawl.name))
+			continue
+		}
+
+		metric := crawl.metric
+		report.WriteString(fmt.Sprintf("%s: outcome %s\n", crawl.name, metric.Outcome.Category))
+		report.WriteString(fmt.Sprintf("\tCookies: %d (third-party %d, tracker %d)\n",
+			metric.TotalCookies, metric.TotalThirdParty, metric.TotalTrackerCookies))
+		report.WriteString(fmt.Sprintf("\tStorage: %d localStorage keys, %d sessionStorage keys\n",
+			metric.LocalStorageKeys, metric.SessionStorageKeys))
+		report.WriteString(fmt.Sprintf("\tRequests: %d third-party, %d tracker, %d third-party hosts\n",
+			metric.ThirdPartyRequests, metric.TrackerRequests, len(metric.ThirdPartyHosts)))
+	}
+
+	if comparison.Baseline == nil || comparison.Signals == nil {
+		report.WriteString("#--------------------------------------------#\n")
+		return report.String()
+	}
+
+	baseline, signals := comparison.BaselineMetrics, comparison.SignalsMetrics
+	report.WriteString(fmt.Sprintf("without -> with signals: %+d cookies, %+d tracker cookies, %+d tracker requests\n",
+		signals.TotalCookies-baseline.TotalCookies, signals.TotalTrackerCookies-baseline.TotalTrackerCookies,
+		signals.TrackerRequests-baseline.TrackerRequests))
+
+	differences := []struct {
+		name           string
+		added, removed []string
+	}{
+		{"Cookies", cookieDifference(comparison.Signals, comparison.Baseline), cookieDifference(comparison.Baseline, comparison.Signals)},
+		{"Storage", storageDifference(comparison.Signals, comparison.Baseline), storageDifference(comparison.Baseline, comparison.Signals)},
+		{"Third-Party Hosts", hostDifference(signals, baseline), hostDifference(baseline, signals)},
+	}
+	for _, difference := range differences {
+		report.WriteString(fmt.Sprintf("%s: %d added, %d removed\n", difference.name, len(difference.added), len(difference.removed)))
+		for _, name := range difference.added {
+			report.WriteString(fmt.Sprintf("\t+ %s\n", name))
+		}
+		for _, name := range difference.removed {
+			report.WriteString(fmt.Sprintf("\t- %s\n", name))
+		}
+	}
+
+	report.WriteString(fmt.Sprintf("Signals Honored: %s\n", gpcVerdict(comparison)))
+	report.WriteString("#--------------------------------------------#\n")
+
+	return report.String()
+}
+
+// Function: GPC Verdict
+// Operation: Judges from the tracker cookies and requests whether the signals
+// changed what the site does, and whether that matches its gpc.json.
+// Return: String
+func gpcVerdict(comparison *GPCComparison) string {
+	baseline, signals := comparison.BaselineMetrics, comparison.SignalsMetrics
+
+	if baseline.TotalTrackerCookies == 0 && baseline.TrackerRequests == 0 {
+		return "unknown, no tracking without signals"
+	}
+
+	reduced := signals.TotalTrackerCookies < baseline.TotalTrackerCookies ||
+		signals.TrackerRequests < baseline.TrackerRequests
+	stopped := signals.TotalTrackerCookies == 0 && signals.TrackerRequests == 0
+	claimed := comparison.Support.Found && comparison.Support.GPC
+
+	switch {
+	case stopped:
+		return "yes, no tracking with signals"
+	case reduced && claimed:
+		return "partly, tracking reduced but gpc.json claims support"
+	case reduced:
+		return "partly, tracking reduced"
+	case claimed:
+		return "no, tracking unchanged although gpc.json claims support"
+	default:
+		return "no, tracking unchanged"
+	}
+}
+
+// Function: Storage Difference
+// Operation: Lists the storage keys in list a that are not in list b, as
+// origin kind key, with IndexedDB databases listed by name.
+// Return: []string, sorted
+func storageDifference(a, b *CookiesList) []string {
+	keys := func(list *CookiesList) map[string]bool {
+		found := make(map[string]bool)
+		if list == nil {
+			return found
+		}
+		for _, origin := range list.Storage {
+			for _, item := range origin.LocalStorage {
+				found[origin.Origin+" local "+item.Key] = true
+			}
+			for _, item := range origin.SessionStorage {
+				found[origin.Origin+" session "+item.Key] = true
+			}
+			for _, database := range origin.IndexedDB {
+				found[origin.Origin+" IndexedDB "+database.Name] = true
+			}
+		}
+		return found
+	}
+
+	inB := keys(b)
+	var names []string
+	for key := range keys(a) {
+		if !inB[key] {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Function: Host Difference
+// Operation: Lists the third-party hosts of metric a that are not in metric b.
+// Return: []string, sorted
+func hostDifference(a, b PrivacyMetric) []string {
+	inB := make(map[string]bool)
+	for _, host := range b.ThirdPartyHosts {
+		inB[host.Host] = true
+	}
+
+	var hosts []string
+	for _, host := range a.ThirdPartyHosts {
+		if !inB[host.Host] {
+			hosts = append(hosts, host.Host)
+		}
+	}
+	sort.Strings(hosts)
+
+	return hosts
+}
+
+// Function: Append GPC To File
+// Operation: Appends the GPC comparison report to GPC.txt with timestamp and metadata
+// Return: Error
+func AppendGPCToFile(report, url, browser string) error {
+	// Open file in append mode, create if it doesn't exist
+	file, err := os.OpenFile(GPCREPORTFILE, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", GPCREPORTFILE, err)
+	}
+	defer file.Close()
+
+	// Create a formatted entry with timestamp and metadata
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	entry := "\n=== GPC Comparison Report ===\n"
+	entry += fmt.Sprintf("Timestamp: %s\n", timestamp)
+	entry += fmt.Sprintf("URL: %s\n", url)
+	entry += fmt.Sprintf("Browser: %s\n", browser)
+	entry += fmt.Sprintf("Report:\n%s\n", report)
+	entry += "=== End Report ===\n\n"
+
+	// Write the entry to the file
+	_, err = file.WriteString(entry)
+	if err != nil {
+		return fmt.Errorf("failed to write to %s: %v", GPCREPORTFILE, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,435 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Header Finding: Represents the audit of one security or privacy header.
+type HeaderFinding struct {
+	Header string
+	Value  string // Empty if the header is missing.
+	Score  int
+	Max    int
+	Issues []string
+}
+
+// Header Audit: Represents the response headers of the landing page, scored
+// from 0 to 100. Error is set, and Findings empty, if the page could not be fetched.
+type HeaderAudit struct {
+	Status   int
+	Score    int
+	Findings []HeaderFinding
+	Error    string
+}
+
+// ---- Global Definitions ---- //
+
+// Points of each header, adding up to 100.
+const (
+	CSP_POINTS                = 25
+	HSTS_POINTS               = 20
+	REFERRER_POLICY_POINTS    = 15
+	PERMISSIONS_POLICY_POINTS = 10
+	FRAME_OPTIONS_POINTS      = 10
+	CONTENT_TYPE_POINTS       = 10
+	COOP_POINTS               = 5
+	COEP_POINTS               = 5
+)
+
+// HSTS max-age, in seconds, needed for full points (one year, as required for preloading).
+const HSTS_MIN_MAX_AGE int = 31536000
+
+// Referrer policies and the points they earn. Policies that send the full URL
+// to other sites earn the least.
+var referrerPolicyPoints = map[string]int{
+	"no-referrer":                     REFERRER_POLICY_POINTS,
+	"same-origin":                     REFERRER_POLICY_POINTS,
+	"strict-origin":                   REFERRER_POLICY_POINTS,
+	"strict-origin-when-cross-origin": 12,
+	"origin":                          8,
+	"origin-when-cross-origin":        8,
+	"no-referrer-when-downgrade":      4,
+	"unsafe-url":                      0,
+}
+
+// Powerful features that should not be delegated to every origin.
+var sensitiveFeatures = []string{"camera", "microphone", "geolocation", "payment", "usb"}
+
+// ---- Functions ---- //
+
+// Function: Fetch Header Audit
+// Operation: Fetches the URL with the browser's User-Agent and audits its response
+// headers. FetchLandingPage gives the same audit along with the TLS and redirects.
+// Return: HeaderAudit
+func FetchHeaderAudit(ctx context.Context, client *HTTPClient, url, userAgent string, verbose *bool) HeaderAudit {
+	return FetchLandingPage(ctx, client, url, url, userAgent, nil, verbose).Headers
+}
+
+// Function: Header Audit Of
+// Operation: Audits the headers of the final response of the landing page.
+// Return: HeaderAudit
+func headerAuditOf(response *http.Response) HeaderAudit {
+	audit := AuditHeaders(response.Header)
+	audit.Status = response.StatusCode
+	return audit
+}
+
+// Function: Audit Headers
+// Operation: Parses and scores the security and privacy headers of a response.
+// Return: HeaderAudit
+func AuditHeaders(headers http.Header) HeaderAudit {
+	csp := auditCSP(headers)
+
+	audit := HeaderAudit{
+		Findings: []HeaderFinding{
+			csp.finding,
+			auditHSTS(headers.Get("Strict-Transport-Security")),
+			auditReferrerPolicy(headers.Get("Referrer-Policy")),
+			auditPermissionsPolicy(headers.Get("Permissions-Policy"), headers.Get("Feature-Policy")),
+			auditFrameOptions(headers.Get("X-Frame-Options"), csp.directives),
+			auditContentTypeOptions(headers.Get("X-Content-Type-Options")),
+			auditCrossOriginPolicy("Cross-Origin-Opener-Policy", headers.Get("Cross-Origin-Opener-Policy"), COOP_POINTS,
+				map[string]int{"same-origin": COOP_POINTS, "same-origin-allow-popups": 3, "noopener-allow-popups": 3}),
+			auditCrossOriginPolicy("Cross-Origin-Embedder-Policy", headers.Get("Cross-Origin-Embedder-Policy"), COEP_POINTS,
+				map[string]int{"require-corp": COEP_POINTS, "credentialless": COEP_POINTS}),
+		},
+	}
+
+	for _, finding := range audit.Findings {
+		audit.Score += finding.Score
+	}
+	return audit
+}
+
+// cspAudit: The CSP finding and the directives of the enforced policy,
+// which the X-Frame-Options audit also reads.
+type cspAudit struct {
+	finding    HeaderFinding
+	directives map[string][]string
+}
+
+// Function: Parse CSP
+// Operation: Splits a policy into directives; names are lowercased, the first
+// occurrence of a directive wins as in browsers.
+// Return: map of directive to source list
+func parseCSP(policy string) map[string][]string {
+	directives := make(map[string][]string)
+	for _, part := range strings.Split(policy, ";") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		name := strings.ToLower(fields[0])
+		if _, ok := directives[name]; !ok {
+			directives[name] = fields[1:]
+		}
+	}
+	return directives
+}
+
+// Function: Audit CSP
+// Operation: Scores the Content-Security-Policy on how far it restricts scripts,
+// plugins and framing. A report-only policy is not enforced and earns few points.
+// Return: cspAudit
+func auditCSP(headers http.Header) cspAudit {
+	finding := HeaderFinding{Header: "Content-Security-Policy", Max: CSP_POINTS}
+
+	policies := headers.Values("Content-Security-Policy")
+	if len(policies) == 0 {
+		if reportOnly := headers.Get("Content-Security-Policy-Report-Only"); reportOnly != "" {
+			finding.Value = reportOnly
+			finding.Score = 5
+			finding.Issues = append(finding.Issues, "report-only, not enforced")
+		} else {
+			finding.Issues = append(finding.Issues, "missing")
+		}
+		return cspAudit{finding: finding}
+	}
+
+	// Several policies are all enforced; only the first is scored.
+	finding.Value = policies[0]
+	if len(policies) > 1 {
+		finding.Issues = append(finding.Issues, fmt.Sprintf("%d policies, only the first is scored", len(policies)))
+	}
+	directives := parseCSP(policies[0])
+	score := CSP_POINTS
+
+	scripts, ok := directives["script-src"]
+	if !ok {
+		scripts, ok = directives["default-src"]
+	}
+	if !ok {
+		score -= 10
+		finding.Issues = append(finding.Issues, "no script-src or default-src, scripts are unrestricted")
+	} else {
+		hasNonce := false
+		for _, source := range scripts {
+			source = strings.ToLower(source)
+			if strings.HasPrefix(source, "'nonce-") || strings.HasPrefix(source, "'sha") || source == "'strict-dynamic'" {
+				hasNonce = true
+			}
+		}
+
+		for _, source := range scripts {
+			switch strings.ToLower(source) {
+			case "'unsafe-inline'":
+				// Browsers ignore 'unsafe-inline' when a nonce or hash is given.
+				if !hasNonce {
+					score -= 8
+					finding.Issues = append(finding.Issues, "'unsafe-inline' scripts allowed")
+				}
+			case "'unsafe-eval'":
+				score -= 5
+				finding.Issues = append(finding.Issues, "'unsafe-eval' allowed")
+			case "*", "http:", "https:", "data:":
+				score -= 7
+				finding.Issues = append(finding.Issues, fmt.Sprintf("scripts allowed from %s", source))
+			}
+		}
+	}
+
+	if !directiveIsNone(directives, "object-src") {
+		score -= 3
+		finding.Issues = append(finding.Issues, "object-src is not 'none'")
+	}
+	if _, ok := directives["frame-ancestors"]; !ok {
+		finding.Issues = append(finding.Issues, "no frame-ancestors")
+	}
+
+	finding.Score = max(score, 0)
+	return cspAudit{finding: finding, directives: directives}
+}
+
+// Function: Directive Is None
+// Operation: Reports whether the directive, or default-src in its place, is 'none'.
+// Return: bool
+func directiveIsNone(directives map[string][]string, name string) bool {
+	sources, ok := directives[name]
+	if !ok {
+		sources = directives["default-src"]
+	}
+	return len(sources) == 1 && strings.ToLower(sources[0]) == "'none'"
+}
+
+// Function: Audit HSTS
+// Operation: Scores Strict-Transport-Security on max-age and includeSubDomains,
+// and notes the preload flag.
+// Return: HeaderFinding
+func auditHSTS(value string) HeaderFinding {
+	finding := HeaderFinding{Header: "Strict-Transport-Security", Value: value, Max: HSTS_POINTS}
+	if value == "" {
+		finding.Issues = append(finding.Issues, "missing")
+		return finding
+	}
+
+	maxAge, includeSubDomains, preload := parseHSTS(value)
+
+	switch {
+	case maxAge < 0:
+		finding.Issues = append(finding.Issues, "no valid max-age, header is ignored")
+		return finding
+	case maxAge == 0:
+		finding.Issues = append(finding.Issues, "max-age=0 turns HSTS off")
+		return finding
+	}
+
+	score := HSTS_POINTS
+	if maxAge < HSTS_MIN_MAX_AGE {
+		score -= 5
+		finding.Issues = append(finding.Issues, fmt.Sprintf("max-age %d is below one year", maxAge))
+	}
+	if !includeSubDomains {
+		score -= 5
+		finding.Issues = append(finding.Issues, "no includeSubDomains")
+	}
+	if preload {
+		finding.Issues = append(finding.Issues, "preload requested")
+	}
+
+	finding.Score = score
+	return finding
+}
+
+// Function: Parse HSTS
+// Operation: Reads the directives of a Strict-Transport-Security header.
+// Return: max-age (int, -1 if missing or invalid), includeSubDomains (bool), preload (bool)
+func parseHSTS(value string) (int, bool, bool) {
+	maxAge := -1
+	includeSubDomains, preload := false, false
+	for _, part := range strings.Split(value, ";") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "max-age":
+			if age, err := strconv.Atoi(strings.Trim(strings.TrimSpace(arg), `"`)); err == nil {
+				maxAge = age
+			}
+		case "includesubdomains":
+			includeSubDomains = true
+		case "preload":
+			preload = true
+		}
+	}
+	return maxAge, includeSubDomains, preload
+}
+
+// Function: Audit Referrer Policy
+// Operation: Scores Referrer-Policy by how much of the URL it sends to other sites.
+// The last policy the browser knows wins, as in browsers.
+// Return: HeaderFinding
+func auditReferrerPolicy(value string) HeaderFinding {
+	finding := HeaderFinding{Header: "Referrer-Policy", Value: value, Max: REFERRER_POLICY_POINTS}
+	if value == "" {
+		finding.Issues = append(finding.Issues, "missing, browsers default to strict-origin-when-cross-origin")
+		return finding
+	}
+
+	policy := ""
+	for _, token := range strings.Split(value, ",") {
+		token = strings.ToLower(strings.TrimSpace(token))
+		if _, ok := referrerPolicyPoints[token]; ok {
+			policy = token
+		}
+	}
+	if policy == "" {
+		finding.Issues = append(finding.Issues, "no valid policy")
+		return finding
+	}
+
+	finding.Score = referrerPolicyPoints[policy]
+	switch policy {
+	case "unsafe-url", "no-referrer-when-downgrade":
+		finding.Issues = append(finding.Issues, fmt.Sprintf("%s sends the full URL to other sites", policy))
+	case "origin", "origin-when-cross-origin":
+		finding.Issues = append(finding.Issues, fmt.Sprintf("%s sends the origin over plain HTTP", policy))
+	}
+	return finding
+}
+
+// Function: Audit Permissions Policy
+// Operation: Scores Permissions-Policy on disabling interest-based advertising
+// and on not delegating powerful features to every origin.
+// Return: HeaderFinding
+func auditPermissionsPolicy(value, featurePolicy string) HeaderFinding {
+	finding := HeaderFinding{Header: "Permissions-Policy", Value: value, Max: PERMISSIONS_POLICY_POINTS}
+	if value == "" {
+		if featurePolicy != "" {
+			finding.Issues = append(finding.Issues, "only the deprecated Feature-Policy is set")
+		} else {
+			finding.Issues = append(finding.Issues, "missing")
+		}
+		return finding
+	}
+
+	// Structured field: feature=(allowlist), feature=*, ...
+	features := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		name, allowlist, _ := strings.Cut(strings.TrimSpace(part), "=")
+		features[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(allowlist)
+	}
+
+	score := 6
+	if features["browsing-topics"] == "()" || features["interest-cohort"] == "()" {
+		score += 4
+	} else {
+		finding.Issues = append(finding.Issues, "browsing-topics is not disabled")
+	}
+	for _, feature := range sensitiveFeatures {
+		if allowlist, ok := features[feature]; ok && strings.Contains(allowlist, "*") {
+			score -= 2
+			finding.Issues = append(finding.Issues, fmt.Sprintf("%s allowed for every origin", feature))
+		}
+	}
+
+	finding.Score = max(score, 0)
+	return finding
+}
+
+// Function: Audit Frame Options
+// Operation: Scores X-Frame-Options; a CSP frame-ancestors directive takes its place.
+// Return: HeaderFinding
+func auditFrameOptions(value string, cspDirectives map[string][]string) HeaderFinding {
+	finding := HeaderFinding{Header: "X-Frame-Options", Value: value, Max: FRAME_OPTIONS_POINTS}
+
+	if _, ok := cspDirectives["frame-ancestors"]; ok {
+		finding.Score = FRAME_OPTIONS_POINTS
+		finding.Issues = append(finding.Issues, "framing set by CSP frame-ancestors")
+		return finding
+	}
+
+	option := strings.ToUpper(strings.TrimSpace(value))
+	switch {
+	case option == "":
+		finding.Issues = append(finding.Issues, "missing")
+	case option == "DENY" || option == "SAMEORIGIN":
+		finding.Score = FRAME_OPTIONS_POINTS
+	case strings.HasPrefix(option, "ALLOW-FROM"):
+		finding.Score = 3
+		finding.Issues = append(finding.Issues, "ALLOW-FROM is ignored by browsers")
+	default:
+		finding.Issues = append(finding.Issues, "invalid value")
+	}
+	return finding
+}
+
+// Function: Audit Content Type Options
+// Operation: Scores X-Content-Type-Options, which only has the value nosniff.
+// Return: HeaderFinding
+func auditContentTypeOptions(value string) HeaderFinding {
+	finding := HeaderFinding{Header: "X-Content-Type-Options", Value: value, Max: CONTENT_TYPE_POINTS}
+
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "nosniff":
+		finding.Score = CONTENT_TYPE_POINTS
+	case "":
+		finding.Issues = append(finding.Issues, "missing")
+	default:
+		finding.Issues = append(finding.Issues, "invalid value")
+	}
+	return finding
+}
+
+// Function: Audit Cross Origin Policy
+// Operation: Scores COOP or COEP from the points of each value. Report endpoints
+// after ";" are ignored.
+// Return: HeaderFinding
+func auditCrossOriginPolicy(header, value string, points int, values map[string]int) HeaderFinding {
+	finding := HeaderFinding{Header: header, Value: value, Max: points}
+
+	policy, _, _ := strings.Cut(value, ";")
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	switch score, ok := values[policy]; {
+	case policy == "":
+		finding.Issues = append(finding.Issues, "missing")
+	case ok:
+		finding.Score = score
+	case policy == "unsafe-none":
+		finding.Issues = append(finding.Issues, "unsafe-none")
+	default:
+		finding.Issues = append(finding.Issues, "invalid value")
+	}
+	return finding
+}
+
+// Function: Write Header Audit
+// Operation: Writes the header score and each finding for reports.
+// Return: None
+func writeHeaderAudit(report *strings.Builder, audit HeaderAudit) {
+	if audit.Error != "" {
+		report.WriteString(fmt.Sprintf("Header Audit Error: %s\n", audit.Error))
+		return
+	}
+
+	report.WriteString(fmt.Sprintf("Header Score: %d\n", audit.Score))
+	for _, finding := range audit.Findings {
+		report.WriteString(fmt.Sprintf("\t%s: %d/%d\n", finding.Header, finding.Score, finding.Max))
+		for _, issue := range finding.Issues {
+			report.WriteString(fmt.Sprintf("\t\t%s\n", issue))
+		}
+	}
+}
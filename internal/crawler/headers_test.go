@@ -0,0 +1,185 @@
+package crawler
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestAuditHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string][]string
+		score   int
+		scores  map[string]int    // Score of each header, 0 for those left out.
+		issues  map[string]string // One issue each header must have.
+	}{
+		{
+			name:    "no headers",
+			headers: map[string][]string{},
+			score:   0,
+			issues: map[string]string{
+				"Content-Security-Policy":   "missing",
+				"Strict-Transport-Security": "missing",
+				"Referrer-Policy":           "missing, browsers default to strict-origin-when-cross-origin",
+				"Permissions-Policy":        "missing",
+				"X-Frame-Options":           "missing",
+				"X-Content-Type-Options":    "missing",
+			},
+		},
+		{
+			name: "strict",
+			headers: map[string][]string{
+				"Content-Security-Policy":      {"default-src 'self'; script-src 'self' 'nonce-r4nd0m' 'unsafe-inline'; object-src 'none'; frame-ancestors 'none'"},
+				"Strict-Transport-Security":    {"max-age=63072000; includeSubDomains; preload"},
+				"Referrer-Policy":              {"no-referrer"},
+				"Permissions-Policy":           {"browsing-topics=(), camera=(), geolocation=(self)"},
+				"X-Content-Type-Options":       {"nosniff"},
+				"Cross-Origin-Opener-Policy":   {"same-origin"},
+				"Cross-Origin-Embedder-Policy": {"require-corp"},
+			},
+			score: 100,
+			scores: map[string]int{
+				"Content-Security-Policy": 25, "Strict-Transport-Security": 20, "Referrer-Policy": 15,
+				"Permissions-Policy": 10, "X-Frame-Options": 10, "X-Content-Type-Options": 10,
+				"Cross-Origin-Opener-Policy": 5, "Cross-Origin-Embedder-Policy": 5,
+			},
+			issues: map[string]string{
+				"Strict-Transport-Security": "preload requested",
+				"X-Frame-Options":           "framing set by CSP frame-ancestors",
+			},
+		},
+		{
+			name: "weak",
+			headers: map[string][]string{
+				"Content-Security-Policy":      {"script-src * 'unsafe-inline' 'unsafe-eval'"},
+				"Strict-Transport-Security":    {"max-age=3600"},
+				"Referrer-Policy":              {"unsafe-url"},
+				"Permissions-Policy":           {"camera=*, geolocation=*"},
+				"X-Frame-Options":              {"ALLOW-FROM https://partner.example"},
+				"X-Content-Type-Options":       {"sniff"},
+				"Cross-Origin-Opener-Policy":   {"unsafe-none"},
+				"Cross-Origin-Embedder-Policy": {`credentialless; report-to="coep"`},
+			},
+			score: 22,
+			scores: map[string]int{
+				"Content-Security-Policy": 2, "Strict-Transport-Security": 10, "Permissions-Policy": 2,
+				"X-Frame-Options": 3, "Cross-Origin-Embedder-Policy": 5,
+			},
+			issues: map[string]string{
+				"Content-Security-Policy":    "scripts allowed from *",
+				"Strict-Transport-Security":  "max-age 3600 is below one year",
+				"Referrer-Policy":            "unsafe-url sends the full URL to other sites",
+				"Permissions-Policy":         "camera allowed for every origin",
+				"X-Frame-Options":            "ALLOW-FROM is ignored by browsers",
+				"X-Content-Type-Options":     "invalid value",
+				"Cross-Origin-Opener-Policy": "unsafe-none",
+			},
+		},
+		{
+			name: "report-only and legacy headers",
+			headers: map[string][]string{
+				"Content-Security-Policy-Report-Only": {"default-src 'self'"},
+				"Strict-Transport-Security":           {"max-age=0"},
+				"Referrer-Policy":                     {"no-referrer, strict-origin-when-cross-origin, not-a-policy"},
+				"Feature-Policy":                      {"camera 'none'"},
+				"X-Frame-Options":                     {"sameorigin"},
+				"X-Content-Type-Options":              {"NoSniff"},
+				"Cross-Origin-Opener-Policy":          {"same-origin-allow-popups"},
+			},
+			score: 40,
+			scores: map[string]int{
+				"Content-Security-Policy": 5, "Referrer-Policy": 12, "X-Frame-Options": 10,
+				"X-Content-Type-Options": 10, "Cross-Origin-Opener-Policy": 3,
+			},
+			issues: map[string]string{
+				"Content-Security-Policy":   "report-only, not enforced",
+				"Strict-Transport-Security": "max-age=0 turns HSTS off",
+				"Permissions-Policy":        "only the deprecated Feature-Policy is set",
+			},
+		},
+		{
+			name: "several policies and quoted max-age",
+			headers: map[string][]string{
+				"Content-Security-Policy":   {"default-src 'none'", "script-src *"},
+				"Strict-Transport-Security": {`max-age="31536000"; includeSubDomains`},
+				"Referrer-Policy":           {"origin"},
+				"X-Frame-Options":           {"DENY"},
+			},
+			score: 63,
+			scores: map[string]int{
+				"Content-Security-Policy": 25, "Strict-Transport-Security": 20, "Referrer-Policy": 8,
+				"X-Frame-Options": 10,
+			},
+			issues: map[string]string{
+				"Content-Security-Policy": "2 policies, only the first is scored",
+				"Referrer-Policy":         "origin sends the origin over plain HTTP",
+			},
+		},
+		{
+			name: "invalid HSTS and frame options",
+			headers: map[string][]string{
+				"Strict-Transport-Security": {"includeSubDomains"},
+				"X-Frame-Options":           {"ALLOWALL"},
+				"Referrer-Policy":           {"everything"},
+			},
+			score: 0,
+			issues: map[string]string{
+				"Strict-Transport-Security": "no valid max-age, header is ignored",
+				"X-Frame-Options":           "invalid value",
+				"Referrer-Policy":           "no valid policy",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		headers := make(http.Header)
+		for name, values := range test.headers {
+			for _, value := range values {
+				headers.Add(name, value)
+			}
+		}
+
+		audit := AuditHeaders(headers)
+		if audit.Score != test.score {
+			t.Errorf("%s: score %d, want %d", test.name, audit.Score, test.score)
+		}
+		if len(audit.Findings) != 8 {
+			t.Fatalf("%s: %d findings, want 8", test.name, len(audit.Findings))
+		}
+
+		for _, finding := range audit.Findings {
+			if finding.Score != test.scores[finding.Header] {
+				t.Errorf("%s: %s scored %d/%d, want %d (issues %q)", test.name, finding.Header, finding.Score,
+					finding.Max, test.scores[finding.Header], finding.Issues)
+			}
+			if issue, ok := test.issues[finding.Header]; ok && !slices.Contains(finding.Issues, issue) {
+				t.Errorf("%s: %s issues %q, want %q", test.name, finding.Header, finding.Issues, issue)
+			}
+		}
+	}
+}
+
+func TestParseHSTS(t *testing.T) {
+	tests := []struct {
+		value             string
+		maxAge            int
+		includeSubDomains bool
+		preload           bool
+	}{
+		{"max-age=31536000", 31536000, false, false},
+		{"max-age=31536000; includeSubDomains; preload", 31536000, true, true},
+		{" MAX-AGE = 600 ;INCLUDESUBDOMAINS", 600, true, false},
+		{`max-age="86400"`, 86400, false, false},
+		{"max-age=soon; preload", -1, false, true},
+		{"", -1, false, false},
+	}
+
+	for _, test := range tests {
+		maxAge, includeSubDomains, preload := parseHSTS(test.value)
+		if maxAge != test.maxAge || includeSubDomains != test.includeSubDomains || preload != test.preload {
+			t.Errorf("parseHSTS(%q) = %d %t %t, want %d %t %t", test.value, maxAge, includeSubDomains, preload,
+				test.maxAge, test.includeSubDomains, test.preload)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package crawler
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// HTTP Client Config: Represents the settings of the client used for requests made
+// outside the browser. Durations are in milliseconds; a timeout of 0 means none.
+type HTTPClientConfig struct {
+	Timeout               int `json:"timeout"` // Whole request, body included.
+	DialTimeout           int `json:"dialTimeout"`
+	TLSHandshakeTimeout   int `json:"tlsHandshakeTimeout"`
+	ResponseHeaderTimeout int `json:"responseHeaderTimeout"`
+
+	// Bytes of a body read, the rest is dropped, and bytes printed in verbose mode.
+	MaxBodySize     int64 `json:"maxBodySize"`
+	VerboseBodySize int   `json:"verboseBodySize"`
+
+	// Proxy URL. Empty uses HTTP_PROXY/HTTPS_PROXY/NO_PROXY, "direct" uses none.
+	Proxy string `json:"proxy"`
+
+	HTTP2 bool `json:"http2"`
+
+	// Sent with every request, the User-Agent is set by the caller.
+	Headers map[string]string `json:"headers"`
+
+	// Keep cookies between requests, shared by every request of the client.
+	CookieJar bool `json:"cookieJar"`
+
+	// Connection reuse: idle connections kept per host and for how long.
+	KeepAlive           bool `json:"keepAlive"`
+	MaxIdleConnsPerHost int  `json:"maxIdleConnsPerHost"`
+	IdleConnTimeout     int  `json:"idleConnTimeout"`
+}
+
+// HTTP Client: Sends requests with one configuration. It is safe for concurrent
+// use and should be shared, so connections are reused across fetches. A nil
+// *HTTPClient uses the default configuration.
+type HTTPClient struct {
+	config HTTPClientConfig
+	client *http.Client
+	proxy  func(*http.Request) (*url.URL, error)
+
+	// Same settings without certificate checks, for TLS inspection. Made when first needed.
+	mu         sync.Mutex
+	unverified *http.Client
+}
+
+// Public Suffix Jar List: Gives the cookie jar the embedded Public Suffix List,
+// so cookies cannot be set for a whole suffix.
+type publicSuffixJarList struct{}
+
+// ---- Global Definitions ---- //
+
+// HTTP Client File: Location of the pre-configured HTTP client settings.
+const HTTPCLIENTFILE string = "internal/config/http_client.json"
+
+// Proxy setting that disables proxies, including those from the environment.
+const PROXY_DIRECT string = "direct"
+
+// Returned, wrapped, with the response and the first MaxBodySize bytes of a longer body.
+var ErrBodyTruncated = errors.New("response body truncated")
+
+// Client used by FetchPacket and by a nil *HTTPClient.
+var (
+	defaultHTTPClient     *HTTPClient
+	defaultHTTPClientOnce sync.Once
+)
+
+// ---- Functions ---- //
+
+// Function: Default HTTP Client Config
+// Operation: Returns the settings used when no HTTP client file is given.
+// Return: HTTPClientConfig
+func DefaultHTTPClientConfig() HTTPClientConfig {
+	return HTTPClientConfig{
+		Timeout:               10000,
+		DialTimeout:           5000,
+		TLSHandshakeTimeout:   5000,
+		ResponseHeaderTimeout: 10000,
+
+		MaxBodySize:     16 * 1024 * 1024,
+		VerboseBodySize: 2048,
+
+		HTTP2: true,
+
+		KeepAlive:           true,
+		MaxIdleConnsPerHost: 4,
+		IdleConnTimeout:     90000,
+	}
+}
+
+// Function: Read HTTP Client Config
+// Operation: Reads the HTTP client settings from the JSON file at the given path.
+// Settings missing from the file keep their default.
+// Return: HTTPClientConfig, Error
+func ReadHTTPClientConfig(path string) (HTTPClientConfig, error) {
+
+	// Read client file into data variable.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return HTTPClientConfig{}, fmt.Errorf("error reading HTTP client file: %v", err)
+	}
+
+	// Parse JSON into data structure, over the defaults.
+	config := DefaultHTTPClientConfig()
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return HTTPClientConfig{}, fmt.Errorf("error parsing HTTP client file: %v", err)
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return HTTPClientConfig{}, err
+	}
+
+	return config, nil
+}
+
+// Function: Validate
+// Operation: Checks the timeouts and sizes are not negative, the body limit is
+// set and the proxy is a URL.
+// Return: Error
+func (config HTTPClientConfig) Validate() error {
+	if config.Timeout < 0 || config.DialTimeout < 0 || config.TLSHandshakeTimeout < 0 ||
+		config.ResponseHeaderTimeout < 0 || config.IdleConnTimeout < 0 {
+		return fmt.Errorf("HTTP client timeouts cannot be negative")
+	}
+	if config.MaxBodySize <= 0 {
+		return fmt.Errorf("HTTP client maxBodySize must be positive")
+	}
+	if config.VerboseBodySize < 0 || config.MaxIdleConnsPerHost < 0 {
+		return fmt.Errorf("HTTP client sizes cannot be negative")
+	}
+
+	_, err := config.proxyFunc()
+	return err
+}
+
+// Function: Proxy Func
+// Operation: Returns the proxy selection of the transport for the Proxy setting.
+// Return: func(*http.Request) (*url.URL, error), Error
+func (config HTTPClientConfig) proxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	switch config.Proxy {
+	case "":
+		return http.ProxyFromEnvironment, nil
+	case PROXY_DIRECT:
+		return nil, nil
+	}
+
+	proxyURL, err := url.Parse(config.Proxy)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid HTTP client proxy %q", config.Proxy)
+	}
+	return http.ProxyURL(proxyURL), nil
+}
+
+// Function: New HTTP Client
+// Operation: Builds a client and its connection pool from the configuration.
+// Return: *HTTPClient, Error
+func NewHTTPClient(config HTTPClientConfig) (*HTTPClient, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &HTTPClient{config: config}
+	c.proxy, _ = config.proxyFunc()
+
+	c.client = &http.Client{
+		Timeout:   milliseconds(config.Timeout),
+		Transport: c.newTransport(&tls.Config{}),
+	}
+	if config.CookieJar {
+		c.client.Jar, err = cookiejar.New(&cookiejar.Options{PublicSuffixList: publicSuffixJarList{}})
+		if err != nil {
+			return nil, fmt.Errorf("could not create cookie jar: %v", err)
+		}
+	}
+
+	return c, nil
+}
+
+// Function: Read HTTP Client
+// Operation: Reads the HTTP client settings from the JSON file at the given path
+// and builds a client with them.
+// Return: *HTTPClient, Error
+func ReadHTTPClient(path string) (*HTTPClient, error) {
+	config, err := ReadHTTPClientConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewHTTPClient(config)
+}
+
+// Function: Or Default
+// Operation: Returns the client, or the shared client with the default configuration if nil.
+// Return: *HTTPClient
+func (c *HTTPClient) orDefault() *HTTPClient {
+	if c != nil {
+		return c
+	}
+
+	defaultHTTPClientOnce.Do(func() {
+		// The default configuration always validates.
+		defaultHTTPClient, _ = NewHTTPClient(DefaultHTTPClientConfig())
+	})
+	return defaultHTTPClient
+}
+
+// Function: New Transport
+// Operation: Builds a transport with the timeouts, proxy, HTTP/2 and connection
+// reuse settings of the client.
+// Return: *http.Transport
+func (c *HTTPClient) newTransport(tlsConfig *tls.Config) *http.Transport {
+	config := c.config
+
+	transport := &http.Transport{
+		Proxy: c.proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   milliseconds(config.DialTimeout),
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   milliseconds(config.TLSHandshakeTimeout),
+		ResponseHeaderTimeout: milliseconds(config.ResponseHeaderTimeout),
+		DisableKeepAlives:     !config.KeepAlive,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		IdleConnTimeout:       milliseconds(config.IdleConnTimeout),
+		ForceAttemptHTTP2:     config.HTTP2,
+	}
+	if !config.HTTP2 {
+		// A non-nil empty map turns HTTP/2 off.
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return transport
+}
+
+// Function: Unverified Client
+// Operation: Returns a client with the same settings that accepts any certificate,
+// so the certificate can be inspected and verified by the caller.
+// Return: *http.Client
+func (c *HTTPClient) unverifiedClient() *http.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.unverified == nil {
+		client := *c.client
+		client.Transport = c.newTransport(&tls.Config{InsecureSkipVerify: true})
+		c.unverified = &client
+	}
+	return c.unverified
+}
+
+// Function: With Check Redirect
+// Operation: Returns a copy of the client, sharing its connections and cookie jar,
+// that calls check before following each redirect.
+// Return: *http.Client
+func withCheckRedirect(client *http.Client, check func(*http.Request, []*http.Request) error) *http.Client {
+	copied := *client
+	copied.CheckRedirect = check
+	return &copied
+}
+
+// Function: Fetch
+// Operation: Sends a request with the configured headers and the User-Agent, and
+// reads the body up to MaxBodySize. The request is abandoned when ctx is done. The
+// response is returned with its body closed, for its status, headers and TLS state.
+// Return: *http.Response, Body ([]byte), Error, ErrBodyTruncated if the body was
+// cut, with the response and body still set
+func (c *HTTPClient) Fetch(ctx context.Context, method, url, userAgent string, body io.Reader, verbose *bool) (*http.Response, []byte, error) {
+	c = c.orDefault()
+	return c.fetch(ctx, c.client, method, url, userAgent, body, verbose)
+}
+
+// Function: Fetch Packet
+// Operation: Sends a GET request and returns the header, body and status code.
+// Return: Header (map), Body ([]byte), Status Code (int), Error, as for Fetch
+func (c *HTTPClient) FetchPacket(ctx context.Context, url string, userAgent string, verbose *bool) (map[string][]string, []byte, int, error) {
+	response, body, err := c.Fetch(ctx, http.MethodGet, url, userAgent, nil, verbose)
+	if response == nil {
+		return nil, nil, 0, err
+	}
+
+	// Return the headers and status code, and ErrBodyTruncated if the body was cut.
+	return response.Header, body, response.StatusCode, err
+}
+
+// Function: Fetch With Client
+// Operation: Same as Fetch, sent with the given client built from c.
+// Return: *http.Response, Body ([]byte), Error
+func (c *HTTPClient) fetch(ctx context.Context, client *http.Client, method, url, userAgent string, body io.Reader, verbose *bool) (*http.Response, []byte, error) {
+
+	// Create a new request.
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	// Configured headers first, the User-Agent is set based on browser.
+	for name, value := range c.config.Headers {
+		request.Header.Set(name, value)
+	}
+	request.Header.Set("User-Agent", userAgent)
+
+	// Make the request.
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error making request: %w", err)
+	}
+
+	// Cleanup on response.
+	defer response.Body.Close()
+
+	// Read the body, at most MaxBodySize bytes of it. One byte more tells a cut body
+	// from one of exactly MaxBodySize bytes.
+	data, err := io.ReadAll(io.LimitReader(response.Body, c.config.MaxBodySize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading response body: %v", err)
+	}
+	truncated := int64(len(data)) > c.config.MaxBodySize
+	if truncated {
+		data = data[:c.config.MaxBodySize]
+	}
+
+	// - Verbose output - //
+	if *verbose {
+		fmt.Printf("\n-- HTTP Packet: Header for %s --\n", url)
+		fmt.Printf("Status: %s (%s)\n", response.Status, response.Proto)
+
+		fmt.Printf("\n-- HTTP Packet: Body for %s --\n\n", url)
+		if len(data) > c.config.VerboseBodySize {
+			fmt.Printf("Body: %s\n... (%d bytes read)\n", string(data[:c.config.VerboseBodySize]), len(data))
+		} else {
+			fmt.Printf("Body: %s\n", string(data))
+		}
+	}
+
+	if truncated {
+		return response, data, fmt.Errorf("%w at %d bytes", ErrBodyTruncated, c.config.MaxBodySize)
+	}
+	return response, data, nil
+}
+
+// Function: Milliseconds
+// Operation: Converts a setting in milliseconds to a duration.
+// Return: time.Duration
+func milliseconds(ms int) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
+
+// Function: Public Suffix
+// Operation: Returns the public suffix of the domain for the cookie jar.
+// Return: String
+func (publicSuffixJarList) PublicSuffix(domain string) string {
+	return publicSuffix(strings.ToLower(domain))
+}
+
+// Function: String
+// Operation: Names the list for the cookie jar.
+// Return: String
+func (publicSuffixJarList) String() string {
+	return "embedded public_suffix_list.dat"
+}
@@ -0,0 +1,235 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Function: Test Client
+// Operation: Builds a client from the default configuration changed by change.
+// Return: *HTTPClient
+func testClient(t *testing.T, change func(config *HTTPClientConfig)) *HTTPClient {
+	config := DefaultHTTPClientConfig()
+	config.Proxy = PROXY_DIRECT
+	change(&config)
+
+	client, err := NewHTTPClient(config)
+	if err != nil {
+		t.Fatalf("NewHTTPClient: %v", err)
+	}
+	return client
+}
+
+func TestFetchBodyLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("x", len(r.URL.Path)-1)))
+	}))
+	defer server.Close()
+
+	client := testClient(t, func(config *HTTPClientConfig) { config.MaxBodySize = 10 })
+	verbose := false
+
+	tests := []struct {
+		size      int
+		body      int
+		truncated bool
+	}{
+		{0, 0, false},
+		{9, 9, false},
+		{10, 10, false},
+		{11, 10, true},
+		{100, 10, true},
+	}
+
+	for _, test := range tests {
+		path := "/" + strings.Repeat("a", test.size)
+		response, body, err := client.Fetch(context.Background(), http.MethodGet, server.URL+path, "", nil, &verbose)
+		if truncated := errors.Is(err, ErrBodyTruncated); truncated != test.truncated || (err != nil && !truncated) {
+			t.Errorf("%d bytes: error %v, want truncated %t", test.size, err, test.truncated)
+		}
+		if response == nil || response.StatusCode != http.StatusOK || len(body) != test.body {
+			t.Errorf("%d bytes: response %v with %d bytes, want 200 with %d", test.size, response, len(body), test.body)
+		}
+
+		// FetchPacket keeps the headers and status of a cut body.
+		headers, _, status, err := client.FetchPacket(context.Background(), server.URL+path, "", &verbose)
+		if errors.Is(err, ErrBodyTruncated) != test.truncated || headers == nil || status != http.StatusOK {
+			t.Errorf("%d bytes: FetchPacket status %d, error %v", test.size, status, err)
+		}
+	}
+}
+
+func TestFetchHeaders(t *testing.T) {
+	received := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header
+	}))
+	defer server.Close()
+
+	client := testClient(t, func(config *HTTPClientConfig) {
+		config.Headers = map[string]string{
+			"Accept-Language": "de-DE,de;q=0.9",
+			"X-Crawl":         "privcrawler",
+			"User-Agent":      "configured",
+		}
+	})
+	verbose := false
+
+	_, _, err := client.Fetch(context.Background(), http.MethodGet, server.URL, "Mozilla/5.0 (test)", nil, &verbose)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := <-received
+	want := map[string]string{
+		"Accept-Language": "de-DE,de;q=0.9",
+		"X-Crawl":         "privcrawler",
+		"User-Agent":      "Mozilla/5.0 (test)", // The browser's, over the configured one.
+	}
+	for name, value := range want {
+		if got := headers.Get(name); got != value {
+			t.Errorf("%s sent as %q, want %q", name, got, value)
+		}
+	}
+}
+
+func TestFetchCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	verbose := false
+	response, _, err := testClient(t, func(*HTTPClientConfig) {}).Fetch(ctx, http.MethodGet, server.URL, "", nil, &verbose)
+	if response != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled fetch gave %v, %v", response, err)
+	}
+}
+
+func TestProxyFunc(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "https://example.com/", nil)
+
+	tests := []struct {
+		proxy string
+		want  string // Proxy chosen for the request, "env" for the environment's.
+		err   bool
+	}{
+		{"", "env", false},
+		{PROXY_DIRECT, "", false},
+		{"http://proxy.example:3128", "http://proxy.example:3128", false},
+		{"socks5://127.0.0.1:1080", "socks5://127.0.0.1:1080", false},
+		{"proxy.example:3128", "", true},
+		{"http://", "", true},
+		{"http://proxy example", "", true},
+	}
+
+	for _, test := range tests {
+		proxy, err := HTTPClientConfig{Proxy: test.proxy}.proxyFunc()
+		if (err != nil) != test.err {
+			t.Errorf("proxy %q: error %v, want error %t", test.proxy, err, test.err)
+			continue
+		}
+
+		switch {
+		case test.err:
+		case test.want == "env":
+			if reflect.ValueOf(proxy).Pointer() != reflect.ValueOf(http.ProxyFromEnvironment).Pointer() {
+				t.Errorf("proxy %q: does not use the environment", test.proxy)
+			}
+		case test.want == "":
+			if proxy != nil {
+				t.Errorf("proxy %q: a proxy is set", test.proxy)
+			}
+		default:
+			proxyURL, err := proxy(request)
+			if err != nil || proxyURL.String() != test.want {
+				t.Errorf("proxy %q: chose %v (%v), want %s", test.proxy, proxyURL, err, test.want)
+			}
+		}
+	}
+}
+
+func TestHTTPClientConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(config *HTTPClientConfig)
+		err    string // Empty if the configuration is valid.
+	}{
+		{"default", func(config *HTTPClientConfig) {}, ""},
+		{"no timeouts", func(config *HTTPClientConfig) { config.Timeout, config.DialTimeout = 0, 0 }, ""},
+		{"negative timeout", func(config *HTTPClientConfig) { config.Timeout = -1 }, "timeouts cannot be negative"},
+		{"negative dial timeout", func(config *HTTPClientConfig) { config.DialTimeout = -1 }, "timeouts cannot be negative"},
+		{"negative TLS timeout", func(config *HTTPClientConfig) { config.TLSHandshakeTimeout = -1 }, "timeouts cannot be negative"},
+		{"negative header timeout", func(config *HTTPClientConfig) { config.ResponseHeaderTimeout = -1 }, "timeouts cannot be negative"},
+		{"negative idle timeout", func(config *HTTPClientConfig) { config.IdleConnTimeout = -1 }, "timeouts cannot be negative"},
+		{"zero body size", func(config *HTTPClientConfig) { config.MaxBodySize = 0 }, "maxBodySize must be positive"},
+		{"negative body size", func(config *HTTPClientConfig) { config.MaxBodySize = -1 }, "maxBodySize must be positive"},
+		{"negative verbose size", func(config *HTTPClientConfig) { config.VerboseBodySize = -1 }, "sizes cannot be negative"},
+		{"negative idle connections", func(config *HTTPClientConfig) { config.MaxIdleConnsPerHost = -1 }, "sizes cannot be negative"},
+		{"invalid proxy", func(config *HTTPClientConfig) { config.Proxy = "proxy:3128" }, "invalid HTTP client proxy"},
+	}
+
+	for _, test := range tests {
+		config := DefaultHTTPClientConfig()
+		test.change(&config)
+
+		err := config.Validate()
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("%s: error %v, want one containing %q", test.name, err, test.err)
+		}
+	}
+}
+
+func TestReadHTTPClientConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// Settings left out of the file keep their default.
+	config, err := ReadHTTPClientConfig(write("partial.json", `{"timeout": 2500, "http2": false, "headers": {"DNT": "1"}}`))
+	if err != nil {
+		t.Fatalf("ReadHTTPClientConfig: %v", err)
+	}
+	want := DefaultHTTPClientConfig()
+	want.Timeout = 2500
+	want.HTTP2 = false
+	want.Headers = map[string]string{"DNT": "1"}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("config %+v, want %+v", config, want)
+	}
+
+	// The shipped file parses and validates.
+	if _, err := ReadHTTPClientConfig(filepath.Join("..", "config", "http_client.json")); err != nil {
+		t.Errorf("shipped config: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		err  string
+	}{
+		{filepath.Join(dir, "missing.json"), "error reading HTTP client file"},
+		{write("broken.json", `{"timeout": `), "error parsing HTTP client file"},
+		{write("invalid.json", `{"maxBodySize": 0}`), "maxBodySize must be positive"},
+	}
+	for _, test := range tests {
+		if _, err := ReadHTTPClientConfig(test.path); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("ReadHTTPClientConfig(%s) error %v, want one containing %q", filepath.Base(test.path), err, test.err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Landing Page: Represents the audits of the landing page fetched outside the browser,
+// all taken from the same fetch.
+type LandingPage struct {
+	Headers   HeaderAudit
+	TLS       TLSInfo
+	Redirects RedirectChain
+}
+
+// ---- Functions ---- //
+
+// Function: Fetch Landing Page
+// Operation: Fetches the URL once, recording every redirect hop, and audits the
+// headers, TLS connection and redirect chain of that fetch, so each site is only
+// fetched once. The client accepts any certificate so invalid ones can be inspected;
+// the headers of such sites are audited all the same. Party is decided against siteURL.
+// Return: LandingPage, with Error set in each audit if the fetch failed
+func FetchLandingPage(ctx context.Context, client *HTTPClient, url, siteURL, userAgent string, preload *HSTSPreloadList, verbose *bool) LandingPage {
+	chain := RedirectChain{URL: url}
+
+	// Called before each redirect is followed, with the response that caused it.
+	client = client.orDefault()
+	recorder := withCheckRedirect(client.unverifiedClient(), func(request *http.Request, via []*http.Request) error {
+		chain.Hops = append(chain.Hops, httpHop(request.Response, siteURL))
+		if len(via) >= MAX_REDIRECTS {
+			return fmt.Errorf("stopped after %d redirects", MAX_REDIRECTS)
+		}
+		return nil
+	})
+
+	// Only the headers and connection are audited, so a cut body does not matter.
+	response, _, err := client.fetch(ctx, recorder, http.MethodGet, url, userAgent, nil, verbose)
+	if err != nil && !errors.Is(err, ErrBodyTruncated) {
+		chain.Error = err.Error()
+		return LandingPage{
+			Headers:   HeaderAudit{Error: err.Error()},
+			TLS:       TLSInfo{PreloadList: preload.versionOf(), Error: err.Error()},
+			Redirects: markBounces(chain),
+		}
+	}
+
+	chain.Hops = append(chain.Hops, httpHop(response, siteURL))
+	chain.FinalURL = response.Request.URL.String()
+
+	return LandingPage{
+		Headers:   headerAuditOf(response),
+		TLS:       tlsInfoOf(response, preload),
+		Redirects: markBounces(chain),
+	}
+}
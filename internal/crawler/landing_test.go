@@ -0,0 +1,60 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFetchLandingPage(t *testing.T) {
+	var hits atomic.Int64
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Path == "/start" {
+			http.SetCookie(w, &http.Cookie{Name: "hop", Value: "1"})
+			http.Redirect(w, r, "/final", http.StatusFound)
+			return
+		}
+		w.Header().Set("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+	}))
+	defer server.Close()
+
+	verbose := false
+	landing := FetchLandingPage(context.Background(), nil, server.URL+"/start", server.URL, "", nil, &verbose)
+
+	// One fetch through the chain serves all three audits.
+	if got := hits.Load(); got != 2 {
+		t.Errorf("server hit %d times, want 2 (one per hop)", got)
+	}
+
+	if landing.Headers.Error != "" || landing.Headers.Status != http.StatusOK || landing.Headers.Score != 30 {
+		t.Errorf("headers = status %d score %d error %q, want 200, 30 and no error", landing.Headers.Status,
+			landing.Headers.Score, landing.Headers.Error)
+	}
+
+	tls := landing.TLS
+	if tls.Error != "" || !tls.HTTPS || tls.URL != server.URL+"/final" || tls.VerifyError == "" {
+		t.Errorf("TLS = %+v, want HTTPS to the final URL with the test certificate unverified", tls)
+	}
+	if tls.HSTS != "max-age=63072000; includeSubDomains" {
+		t.Errorf("TLS HSTS %q, from the wrong response", tls.HSTS)
+	}
+
+	chain := landing.Redirects
+	if chain.Error != "" || chain.FinalURL != server.URL+"/final" || len(chain.Hops) != 2 {
+		t.Fatalf("redirects = %+v, want 2 hops to /final", chain)
+	}
+	if chain.Hops[0].Status != http.StatusFound || len(chain.Hops[0].SetCookies) != 1 || chain.Hops[1].Status != http.StatusOK {
+		t.Errorf("hops = %+v", chain.Hops)
+	}
+
+	// A failed fetch sets the error of every audit.
+	server.Close()
+	landing = FetchLandingPage(context.Background(), nil, server.URL, server.URL, "", nil, &verbose)
+	if landing.Headers.Error == "" || landing.TLS.Error == "" || landing.Redirects.Error == "" {
+		t.Errorf("failed fetch gave %+v", landing)
+	}
+}
@@ -0,0 +1,296 @@
+package crawler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Mixed Content: Represents a subresource of an HTTPS page referenced over plain HTTP.
+type MixedContent struct {
+	URL  string `json:"url"`
+	Host string `json:"-"`
+	Type string `json:"type"` // script, stylesheet, iframe, image, media, object, or a request resource type.
+	Page string `json:"-"`    // URL of the frame that references it.
+
+	// Active content can change the page and is blocked by browsers; passive
+	// content (images, media) is shown or upgraded to HTTPS.
+	Active bool `json:"-"`
+
+	// Loaded is set if the resource was requested over HTTP, it is false for
+	// resources only found in the page because the browser blocked or upgraded them.
+	Loaded bool `json:"-"`
+}
+
+// Insecure Form: Represents a form that submits to a plain HTTP URL.
+type InsecureForm struct {
+	Action   string `json:"action"`
+	Method   string `json:"method"`
+	Page     string `json:"-"`
+	Password bool   `json:"password"` // Form has a password field.
+}
+
+// ---- Global Definitions ---- //
+
+// Lists the http: subresources and form targets of the frame. Attributes are read
+// rather than properties, as browsers rewrite upgraded URLs.
+const mixedContentScript string = `() => {
+	const result = { page: location.href, secure: location.protocol === 'https:', resources: [], forms: [] };
+	const resolve = (link) => {
+		try { return new URL(link, document.baseURI); } catch (e) { return null; }
+	};
+	const add = (selector, attribute, type) => {
+		document.querySelectorAll(selector).forEach((element) => {
+			const link = resolve(element.getAttribute(attribute));
+			if (link && link.protocol === 'http:') {
+				result.resources.push({ url: link.href, type: type });
+			}
+		});
+	};
+	add('script[src]', 'src', 'script');
+	add('link[rel~="stylesheet"][href]', 'href', 'stylesheet');
+	add('iframe[src], frame[src]', 'src', 'iframe');
+	add('object[data]', 'data', 'object');
+	add('embed[src]', 'src', 'object');
+	add('img[src]', 'src', 'image');
+	add('audio[src], video[src], source[src]', 'src', 'media');
+	document.querySelectorAll('form').forEach((form) => {
+		const link = resolve(form.getAttribute('action') || location.href);
+		if (link && link.protocol === 'http:') {
+			result.forms.push({
+				action: link.href,
+				method: (form.getAttribute('method') || 'get').toLowerCase(),
+				password: form.querySelector('input[type="password"]') !== null,
+			});
+		}
+	});
+	return result;
+}`
+
+// Mixed content browsers show or upgrade instead of blocking.
+var passiveContentTypes = map[string]bool{
+	"image": true,
+	"media": true,
+}
+
+// ---- Functions ---- //
+
+// Function: Capture Mixed Content
+// Operation: Reads the http: subresources of every HTTPS frame on the page and the
+// http: form targets of every frame, keyed by URL. The first page a URL is found
+// on is kept.
+// Return: None
+func captureMixedContent(page playwright.Page, mixed map[string]MixedContent, forms map[string]InsecureForm) {
+	for _, frame := range page.Frames() {
+		result, err := frame.Evaluate(mixedContentScript)
+		if err != nil {
+			continue
+		}
+
+		// Round-trip through JSON to map the result onto the structures.
+		data, err := json.Marshal(result)
+		if err != nil {
+			continue
+		}
+		var found struct {
+			Page      string         `json:"page"`
+			Secure    bool           `json:"secure"`
+			Resources []MixedContent `json:"resources"`
+			Forms     []InsecureForm `json:"forms"`
+		}
+		if json.Unmarshal(data, &found) != nil {
+			continue
+		}
+
+		if found.Secure {
+			for _, resource := range found.Resources {
+				if _, ok := mixed[resource.URL]; ok {
+					continue
+				}
+				resource.Host = hostOf(resource.URL)
+				resource.Page = found.Page
+				resource.Active = !passiveContentTypes[resource.Type]
+				mixed[resource.URL] = resource
+			}
+		}
+
+		for _, form := range found.Forms {
+			key := form.Method + " " + form.Action
+			if _, ok := forms[key]; ok {
+				continue
+			}
+			form.Page = found.Page
+			forms[key] = form
+		}
+	}
+}
+
+// Function: Insecure Requests
+// Operation: Returns the requests of the visit sent over plain HTTP, navigations included.
+// Return: []NetworkRequest
+func insecureRequests(requests []NetworkRequest) []NetworkRequest {
+	var insecure []NetworkRequest
+	for _, request := range requests {
+		if strings.HasPrefix(request.URL, "http:") {
+			insecure = append(insecure, request)
+		}
+	}
+	return insecure
+}
+
+// Function: Add Insecure Requests
+// Operation: Marks the mixed content that was requested over HTTP as loaded, and
+// adds HTTP requests made by HTTPS frames that were not found in the page, such as
+// script fetches. Navigations are left to the page scan, as their frame is the
+// one navigating.
+// Return: None
+func addInsecureRequests(mixed map[string]MixedContent, insecure []NetworkRequest) {
+	for _, request := range insecure {
+		if resource, ok := mixed[request.URL]; ok {
+			resource.Loaded = true
+			mixed[request.URL] = resource
+			continue
+		}
+		if request.ResourceType == "document" || !strings.HasPrefix(request.Frame, "https:") {
+			continue
+		}
+
+		mixed[request.URL] = MixedContent{
+			URL:    request.URL,
+			Host:   request.Host,
+			Type:   request.ResourceType,
+			Page:   request.Frame,
+			Active: !passiveContentTypes[request.ResourceType],
+			Loaded: true,
+		}
+	}
+}
+
+// Function: Mixed Content List
+// Operation: Returns the mixed content with active content first, then by URL.
+// Return: []MixedContent
+func mixedContentList(mixed map[string]MixedContent) []MixedContent {
+	list := make([]MixedContent, 0, len(mixed))
+	for _, resource := range mixed {
+		list = append(list, resource)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Active != list[j].Active {
+			return list[i].Active
+		}
+		return list[i].URL < list[j].URL
+	})
+
+	return list
+}
+
+// Function: Insecure Form List
+// Operation: Returns the insecure forms sorted by target.
+// Return: []InsecureForm
+func insecureFormList(forms map[string]InsecureForm) []InsecureForm {
+	list := make([]InsecureForm, 0, len(forms))
+	for _, form := range forms {
+		list = append(list, form)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Action != list[j].Action {
+			return list[i].Action < list[j].Action
+		}
+		return list[i].Method < list[j].Method
+	})
+
+	return list
+}
+
+// Function: Mark Insecure Cookie
+// Operation: Records the first HTTP request the cookie is in scope of, by domain and
+// path. Secure cookies are never sent over HTTP, and SameSite Lax or Strict cookies
+// are only counted on top-level navigations: an HTTP subresource or frame of an
+// HTTPS page is cross-site, so the browser leaves them out.
+// Return: Cookie
+func markInsecureCookie(cookie Cookie, insecure []NetworkRequest) Cookie {
+	if cookie.Secure {
+		return cookie
+	}
+
+	sameSite := cookie.SameSite == "Strict" || cookie.SameSite == "Lax"
+	for _, request := range insecure {
+		if sameSite && !request.MainFrame {
+			continue
+		}
+		if cookieInScope(cookie, request.URL) {
+			cookie.InsecureRequest = request.URL
+			break
+		}
+	}
+
+	return cookie
+}
+
+// Function: Cookie In Scope
+// Operation: Reports whether a browser sends the cookie with a request to the link,
+// by the domain and path matching rules of RFC 6265.
+// Return: bool
+func cookieInScope(cookie Cookie, link string) bool {
+	parsedURL, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(parsedURL.Hostname())
+	domain := strings.ToLower(cookie.Domain)
+	if strings.HasPrefix(domain, ".") {
+		// Domain cookie, sent to the domain and its subdomains.
+		if host != domain[1:] && !strings.HasSuffix(host, domain) {
+			return false
+		}
+	} else if host != domain {
+		return false
+	}
+
+	path := parsedURL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	switch {
+	case cookie.Path == "" || cookie.Path == "/" || path == cookie.Path:
+		return true
+	case strings.HasSuffix(cookie.Path, "/"):
+		return strings.HasPrefix(path, cookie.Path)
+	default:
+		return strings.HasPrefix(path, cookie.Path+"/")
+	}
+}
+
+// Function: Write Mixed Content
+// Operation: Writes the mixed content, insecure form targets and cookies sent over
+// HTTP for reports.
+// Return: None
+func writeMixedContent(report *strings.Builder, privacyMetrics PrivacyMetric) {
+	report.WriteString(fmt.Sprintf("Mixed Content: %d (active %d)\n", len(privacyMetrics.MixedContent),
+		privacyMetrics.ActiveMixedContent))
+	for _, resource := range privacyMetrics.MixedContent {
+		state := "blocked or upgraded"
+		if resource.Loaded {
+			state = "loaded"
+		}
+		report.WriteString(fmt.Sprintf("\t[%s] %s on %s, %s\n", resource.Type, resource.URL, resource.Page, state))
+	}
+
+	report.WriteString(fmt.Sprintf("Insecure Form Targets: %d\n", len(privacyMetrics.InsecureForms)))
+	for _, form := range privacyMetrics.InsecureForms {
+		line := fmt.Sprintf("\t%s %s on %s", strings.ToUpper(form.Method), form.Action, form.Page)
+		if form.Password {
+			line += " (password field)"
+		}
+		report.WriteString(line + "\n")
+	}
+
+	report.WriteString(fmt.Sprintf("Cookies Sent Over HTTP: %d\n", privacyMetrics.InsecureCookies))
+}
@@ -0,0 +1,30 @@
+package crawler
+
+import "testing"
+
+func TestMarkInsecureCookie(t *testing.T) {
+	insecure := []NetworkRequest{
+		{URL: "http://example.com/embed", ResourceType: "document", Frame: "https://example.com/"},
+		{URL: "http://example.com/pixel.gif", ResourceType: "image", Frame: "https://example.com/"},
+		{URL: "http://example.com/account/", ResourceType: "document", Frame: "http://example.com/account/", MainFrame: true},
+	}
+
+	tests := []struct {
+		name   string
+		cookie Cookie
+		want   string
+	}{
+		{"secure", Cookie{Domain: ".example.com", Secure: true}, ""},
+		{"no samesite on a subresource", Cookie{Domain: ".example.com", SameSite: "None"}, "http://example.com/embed"},
+		{"lax skips iframe navigations", Cookie{Domain: "example.com", SameSite: "Lax"}, "http://example.com/account/"},
+		{"strict skips iframe navigations", Cookie{Domain: ".example.com", SameSite: "Strict"}, "http://example.com/account/"},
+		{"path out of scope", Cookie{Domain: "example.com", Path: "/shop", SameSite: "Lax"}, ""},
+		{"other domain", Cookie{Domain: ".example.org"}, ""},
+	}
+
+	for _, test := range tests {
+		if got := markInsecureCookie(test.cookie, insecure).InsecureRequest; got != test.want {
+			t.Errorf("%s: sent over %q, want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Network Request: Represents one request made during a visit and its response.
+type NetworkRequest struct {
+	URL          string
+	Host         string
+	Method       string
+	ResourceType string
+	Frame        string // URL of the frame that made the request, empty for service workers.
+	MainFrame    bool   // Navigation of the top-level frame.
+
+	// Request headers as sent by the page and the request body, cut at MAX_REQUEST_BODY.
+	RequestHeaders map[string]string
+	Body           string
+
+	// Response, Status is 0 and ReceivedAt is zero if there was none.
+	Status     int
+	Headers    map[string]string
+	Size       int // Response headers and body in bytes.
+	ReceivedAt time.Time
+
+	Error        string
+	IsFirstParty bool
+
+	// Tracker in the CNAME chain of a first-party looking host.
+	// Such requests are counted as third-party.
+	CloakedBy string
+}
+
+// Host Traffic: Represents every request made to one host during a visit.
+type HostTraffic struct {
+	Host          string
+	Requests      int
+	Bytes         int
+	Failed        int
+	ResourceTypes map[string]int
+	Tracker       TrackerCategory // Empty if the host is not on a filter list.
+}
+
+// Network Recorder: Collects request events of a browser context. Event handlers
+// run on the Playwright dispatcher, so they only store the events; anything that
+// needs a round trip to the browser is done in collect.
+type networkRecorder struct {
+	mu        sync.Mutex
+	siteURL   string
+	requests  []playwright.Request
+	frames    map[playwright.Request]string
+	mainFrame map[playwright.Request]bool // Navigations of the top-level frame.
+	responses map[playwright.Request]playwright.Response
+	received  map[playwright.Request]time.Time
+}
+
+// ---- Global Definitions ---- //
+
+// Network File: Every request of every crawl, one row per request.
+const NETWORKFILE string = "NETWORK.csv"
+
+// Largest request body kept per request, in bytes.
+const MAX_REQUEST_BODY int = 64 * 1024
+
+// Serializes appends to the CSV files, which the crawls of a scheduler write at once.
+var csvAppendMu sync.Mutex
+
+// ---- Functions ---- //
+
+// Function: New Network Recorder
+// Operation: Starts recording the requests and responses of the browser context.
+// Return: *networkRecorder
+func newNetworkRecorder(browserContext playwright.BrowserContext, siteURL string) *networkRecorder {
+	recorder := &networkRecorder{
+		siteURL:   siteURL,
+		frames:    make(map[playwright.Request]string),
+		mainFrame: make(map[playwright.Request]bool),
+		responses: make(map[playwright.Request]playwright.Response),
+		received:  make(map[playwright.Request]time.Time),
+	}
+
+	browserContext.OnRequest(func(request playwright.Request) {
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+
+		recorder.requests = append(recorder.requests, request)
+		if frame := request.Frame(); frame != nil {
+			recorder.frames[request] = frame.URL()
+			if request.IsNavigationRequest() && frame.ParentFrame() == nil {
+				recorder.mainFrame[request] = true
+			}
+		}
+	})
+
+	browserContext.OnResponse(func(response playwright.Response) {
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+
+		recorder.responses[response.Request()] = response
+		recorder.received[response.Request()] = time.Now()
+	})
+
+	return recorder
+}
+
+// Function: Collect
+// Operation: Turns the recorded events into NetworkRequests. Full headers and sizes
+// are read from the browser, so this must run before the context is closed. When ctx
+// is done only what is already known is kept.
+// Return: []NetworkRequest
+func (r *networkRecorder) collect(ctx context.Context) []NetworkRequest {
+	r.mu.Lock()
+	requests := append([]playwright.Request(nil), r.requests...)
+	frames := make(map[playwright.Request]string, len(r.frames))
+	for request, frame := range r.frames {
+		frames[request] = frame
+	}
+	responses := make(map[playwright.Request]playwright.Response, len(r.responses))
+	for request, response := range r.responses {
+		responses[request] = response
+	}
+	received := make(map[playwright.Request]time.Time, len(r.received))
+	for request, at := range r.received {
+		received[request] = at
+	}
+	mainFrame := make(map[playwright.Request]bool, len(r.mainFrame))
+	for request := range r.mainFrame {
+		mainFrame[request] = true
+	}
+	r.mu.Unlock()
+
+	collected := make([]NetworkRequest, 0, len(requests))
+	for _, request := range requests {
+		entry := NetworkRequest{
+			URL:          request.URL(),
+			Method:       request.Method(),
+			ResourceType: request.ResourceType(),
+			Frame:        frames[request],
+			MainFrame:    mainFrame[request],
+
+			RequestHeaders: request.Headers(),
+		}
+		if body, err := request.PostData(); err == nil {
+			if len(body) > MAX_REQUEST_BODY {
+				body = body[:MAX_REQUEST_BODY]
+			}
+			entry.Body = body
+		}
+		entry.Host = hostOf(entry.URL)
+		entry.IsFirstParty = isFirstParty(entry.Host, r.siteURL)
+
+		if err := request.Failure(); err != nil {
+			entry.Error = err.Error()
+		}
+
+		if response, ok := responses[request]; ok {
+			entry.Status = response.Status()
+			entry.Headers = response.Headers()
+			entry.ReceivedAt = received[request]
+
+			if ctx.Err() == nil {
+				if headers, err := response.AllHeaders(); err == nil {
+					entry.Headers = headers
+				}
+				if sizes, err := request.Sizes(); err == nil {
+					entry.Size = sizes.ResponseHeadersSize + sizes.ResponseBodySize
+				}
+			}
+		}
+
+		collected = append(collected, entry)
+	}
+
+	return collected
+}
+
+// Function: Host Of
+// Operation: Returns the host name of the link, without port.
+// Return: String, empty if the link cannot be parsed
+func hostOf(link string) string {
+	parsedURL, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return parsedURL.Hostname()
+}
+
+// Function: Third-Party Traffic
+// Operation: Sums the third-party requests per host, busiest host first,
+// labelling each host with its tracker category.
+// Return: []HostTraffic
+func thirdPartyTraffic(requests []NetworkRequest, trackers *TrackerLists) []HostTraffic {
+	byHost := make(map[string]*HostTraffic)
+	for _, request := range requests {
+		if request.IsFirstParty || request.Host == "" {
+			continue
+		}
+
+		traffic, ok := byHost[request.Host]
+		if !ok {
+			traffic = &HostTraffic{
+				Host:          request.Host,
+				ResourceTypes: make(map[string]int),
+				Tracker:       requestTracker(request, trackers),
+			}
+			byHost[request.Host] = traffic
+		}
+		traffic.Requests++
+		traffic.Bytes += request.Size
+		traffic.ResourceTypes[request.ResourceType]++
+		if request.Error != "" || request.Status >= 400 {
+			traffic.Failed++
+		}
+	}
+
+	hosts := make([]HostTraffic, 0, len(byHost))
+	for _, traffic := range byHost {
+		hosts = append(hosts, *traffic)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		if hosts[i].Requests != hosts[j].Requests {
+			return hosts[i].Requests > hosts[j].Requests
+		}
+		return hosts[i].Host < hosts[j].Host
+	})
+
+	return hosts
+}
+
+// Function: Request Tracker
+// Operation: Classifies the host of the request, or the tracker it is an alias of.
+// Return: TrackerCategory
+func requestTracker(request NetworkRequest, trackers *TrackerLists) TrackerCategory {
+	if request.CloakedBy != "" {
+		return trackers.Classify(request.CloakedBy)
+	}
+	return trackers.Classify(request.Host)
+}
+
+// Function: Add Requests To Metric
+// Operation: Adds the request counts and the third-party hosts of a visit.
+// Return: None
+func addRequestsToMetric(privacyMetrics *PrivacyMetric, requests []NetworkRequest, trackers *TrackerLists) {
+	for _, request := range requests {
+		privacyMetrics.TotalRequests++
+		privacyMetrics.TotalRequestBytes += request.Size
+		if !request.IsFirstParty {
+			privacyMetrics.ThirdPartyRequests++
+			if requestTracker(request, trackers).IsTracker() {
+				privacyMetrics.TrackerRequests++
+			}
+			if request.CloakedBy != "" {
+				privacyMetrics.CloakedRequests++
+			}
+		}
+	}
+	privacyMetrics.ThirdPartyHosts = thirdPartyTraffic(requests, trackers)
+}
+
+// Function: Append Network To File
+// Operation: Appends the requests of one crawl to NETWORK.csv,
+// writing the header when the file is new.
+// Return: Error
+func AppendNetworkToFile(requests []NetworkRequest, url, browser string) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	rows := make([][]string, 0, len(requests))
+	for _, request := range requests {
+		rows = append(rows, []string{timestamp, url, browser, request.URL, request.Host, request.Method,
+			request.ResourceType, request.Frame, strconv.Itoa(request.Status), strconv.Itoa(request.Size),
+			strconv.FormatBool(request.IsFirstParty), request.Error})
+	}
+
+	return appendCSV(NETWORKFILE, []string{"timestamp", "url", "browser", "request_url", "host", "method",
+		"resource_type", "frame", "status", "size", "first_party", "error"}, rows)
+}
+
+// Function: Append CSV
+// Operation: Appends the rows to the CSV file, writing the header first when the
+// file is new. Request and site URLs may contain commas, so rows are written as
+// proper CSV, and all of them in one write under csvAppendMu so rows of crawls
+// running at once are not interleaved.
+// Return: Error
+func appendCSV(path string, header []string, rows [][]string) error {
+	csvAppendMu.Lock()
+	defer csvAppendMu.Unlock()
+
+	// Open file in append mode, create if it doesn't exist
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	info, err := file.Stat()
+	if err == nil && info.Size() == 0 {
+		writer.Write(header)
+	}
+	writer.WriteAll(rows)
+
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write to %s: %v", path, err)
+	}
+	if _, err := file.Write(buffer.Bytes()); err != nil {
+		return fmt.Errorf("failed to write to %s: %v", path, err)
+	}
+
+	return nil
+}
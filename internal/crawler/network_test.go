@@ -0,0 +1,61 @@
+package crawler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestAppendCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.csv")
+	header := []string{"crawl", "row", "url"}
+
+	// Crawls running at once each append their rows, the URLs holding commas and quotes.
+	const crawls, rowsPerCrawl = 8, 500
+	var wg sync.WaitGroup
+	for crawl := range crawls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rows := make([][]string, rowsPerCrawl)
+			for row := range rows {
+				rows[row] = []string{fmt.Sprint(crawl), fmt.Sprint(row), `https://example.com/a,b?q="1"`}
+			}
+			if err := appendCSV(path, header, rows); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("reading back: %v", err)
+	}
+	if len(records) != 1+crawls*rowsPerCrawl {
+		t.Fatalf("%d records, want %d", len(records), 1+crawls*rowsPerCrawl)
+	}
+	if fmt.Sprint(records[0]) != fmt.Sprint(header) {
+		t.Errorf("header %v, want %v", records[0], header)
+	}
+
+	// Each crawl's rows are written together and in order.
+	for i := 1; i < len(records); i += rowsPerCrawl {
+		crawl := records[i][0]
+		for row := range rowsPerCrawl {
+			record := records[i+row]
+			if record[0] != crawl || record[1] != fmt.Sprint(row) || record[2] != `https://example.com/a,b?q="1"` {
+				t.Fatalf("record %d = %v, interleaved with another crawl", i+row, record)
+			}
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Browser Pool: Keeps one Playwright driver and one browser per engine
+// alive so many crawls can share them. Every crawl gets its own
+// BrowserContext, which keeps cookies and storage isolated between crawls.
+type BrowserPool struct {
+	mu       sync.Mutex
+	pw       *playwright.Playwright
+	browsers map[string]playwright.Browser
+	isHidden bool
+	verbose  bool
+	closed   bool
+
+	// Contexts handed out and not yet closed with CloseContext. The driver is
+	// never restarted while any are in flight.
+	active atomic.Int64
+}
+
+// ---- Global Definitions ---- //
+
+// Engines the pool can launch, see launchBrowser.
+var poolBrowsers = map[string]bool{
+	"chrome":   true,
+	"chromium": true,
+	"firefox":  true,
+	"webkit":   true,
+}
+
+// ---- Functions ---- //
+
+// Function: New Browser Pool
+// Operation: Starts the Playwright driver. Browsers are launched on first use.
+// Return: *BrowserPool, Error
+func NewBrowserPool(isHidden bool, verbose bool) (*BrowserPool, error) {
+	pw, err := playwright.Run()
+	if err != nil {
+		return nil, fmt.Errorf("could not launch playwright: %v", err)
+	}
+
+	return &BrowserPool{
+		pw:       pw,
+		browsers: make(map[string]playwright.Browser),
+		isHidden: isHidden,
+		verbose:  verbose,
+	}, nil
+}
+
+// Function: New Context
+// Operation: Creates a fresh, isolated context on the pooled browser for the engine.
+// A browser that has crashed or disconnected is relaunched first, and the context
+// is retried once if creating it fails, on a new browser only if the old one is gone.
+// Every context must be closed with CloseContext.
+// Return: BrowserContext, Error
+func (bp *BrowserPool) NewContext(browser string, options ...playwright.BrowserNewContextOptions) (playwright.BrowserContext, error) {
+	if !poolBrowsers[browser] {
+		return nil, fmt.Errorf("browser %s is not compatible", browser)
+	}
+
+	launcher, err := bp.getBrowser(browser)
+	if err != nil {
+		return nil, err
+	}
+
+	context, err := launcher.NewContext(options...)
+	if err != nil {
+		// The browser may have died between the health check and now.
+		if bp.verbose {
+			fmt.Printf("could not create context on %s, retrying: %v\n", browser, err)
+		}
+		launcher, err = bp.restartBrowser(browser, launcher)
+		if err != nil {
+			return nil, err
+		}
+
+		context, err = launcher.NewContext(options...)
+		if err != nil {
+			return nil, fmt.Errorf("could not create context: %v", err)
+		}
+	}
+
+	bp.active.Add(1)
+	return context, nil
+}
+
+// Function: Close Context
+// Operation: Closes a context made by NewContext. It is no longer counted as in
+// flight even if closing fails, as it does when the browser is gone.
+// Return: Error
+func (bp *BrowserPool) CloseContext(context playwright.BrowserContext) error {
+	defer bp.active.Add(-1)
+	return context.Close()
+}
+
+// Function: Close
+// Operation: Closes every pooled browser and stops the Playwright driver.
+// Return: Error
+func (bp *BrowserPool) Close() error {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	if bp.closed {
+		return nil
+	}
+	bp.closed = true
+
+	for name, launcher := range bp.browsers {
+		launcher.Close()
+		delete(bp.browsers, name)
+	}
+
+	err := bp.pw.Stop()
+	if err != nil {
+		return fmt.Errorf("could not stop playwright: %v", err)
+	}
+
+	return nil
+}
+
+// Function: Get Browser
+// Operation: Returns the pooled browser for the engine, launching it if it
+// is missing or no longer connected.
+// Return: Browser, Error
+func (bp *BrowserPool) getBrowser(browser string) (playwright.Browser, error) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	if bp.closed {
+		return nil, fmt.Errorf("browser pool is closed")
+	}
+
+	// Health check.
+	launcher, ok := bp.browsers[browser]
+	if ok && launcher.IsConnected() {
+		return launcher, nil
+	}
+	if ok && bp.verbose {
+		fmt.Printf("Browser %s disconnected, relaunching...\n", browser)
+	}
+
+	return bp.launch(browser)
+}
+
+// Function: Restart Browser
+// Operation: Launches a replacement for a disconnected browser, unless another
+// crawl has already replaced it. A browser that is still connected is shared by
+// other crawls, so it is returned to be retried rather than closed.
+// Return: Browser, Error
+func (bp *BrowserPool) restartBrowser(browser string, old playwright.Browser) (playwright.Browser, error) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	if bp.closed {
+		return nil, fmt.Errorf("browser pool is closed")
+	}
+
+	if current, ok := bp.browsers[browser]; ok && current != old && current.IsConnected() {
+		return current, nil
+	}
+	if old.IsConnected() {
+		return old, nil
+	}
+	delete(bp.browsers, browser)
+
+	return bp.launch(browser)
+}
+
+// Function: Launch
+// Operation: Launches the engine and stores it in the pool. A failed launch is
+// retried once for this engine only; the Playwright driver is restarted only when
+// it is dead and no other crawl is using the pool. Caller must hold bp.mu.
+// Return: Browser, Error
+func (bp *BrowserPool) launch(browser string) (playwright.Browser, error) {
+	launcher, err := launchBrowser(bp.pw, browser, bp.isHidden)
+	if err != nil {
+		if bp.verbose {
+			fmt.Printf("could not launch %s, retrying: %v\n", browser, err)
+		}
+		launcher, err = launchBrowser(bp.pw, browser, bp.isHidden)
+	}
+
+	if err != nil && bp.driverDead(err) {
+		if bp.active.Load() > 0 {
+			return nil, fmt.Errorf("playwright driver stopped while %d crawls are running: %v", bp.active.Load(), err)
+		}
+		if bp.verbose {
+			fmt.Printf("playwright driver stopped, restarting: %v\n", err)
+		}
+
+		err = bp.restartDriver()
+		if err != nil {
+			return nil, err
+		}
+		launcher, err = launchBrowser(bp.pw, browser, bp.isHidden)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if bp.verbose {
+		fmt.Printf("Launched pooled browser: %s\n", browser)
+	}
+
+	bp.browsers[browser] = launcher
+	return launcher, nil
+}
+
+// Function: Driver Dead
+// Operation: Reports whether a launch failed because the Playwright connection is
+// closed. A pooled browser that is still connected proves the driver is alive.
+// Caller must hold bp.mu.
+// Return: bool
+func (bp *BrowserPool) driverDead(err error) bool {
+	if !errors.Is(err, playwright.ErrTargetClosed) {
+		return false
+	}
+	for _, launcher := range bp.browsers {
+		if launcher.IsConnected() {
+			return false
+		}
+	}
+	return true
+}
+
+// Function: Restart Driver
+// Operation: Stops the Playwright driver and every browser, then starts a new driver.
+// Only called when the driver is dead and no context is in flight. Caller must hold bp.mu.
+// Return: Error
+func (bp *BrowserPool) restartDriver() error {
+	for name, launcher := range bp.browsers {
+		launcher.Close()
+		delete(bp.browsers, name)
+	}
+	bp.pw.Stop()
+
+	pw, err := playwright.Run()
+	if err != nil {
+		return fmt.Errorf("could not launch playwright: %v", err)
+	}
+	bp.pw = pw
+
+	return nil
+}
+
+// Function: Launch Browser
+// Operation: Launches the browser engine with the given Playwright driver.
+// Return: Browser, Error
+func launchBrowser(pw *playwright.Playwright, browser string, isHidden bool) (playwright.Browser, error) {
+
+	// Declare launcher ahead of time
+	var launcher playwright.Browser
+	var err error
+
+	// launching specific browser (edge is missing)
+	if browser == "chrome" {
+		launcher, err = pw.Chromium.Launch(playwright.BrowserTypeLaunchOptions{
+			Headless: playwright.Bool(isHidden),
+		})
+	} else if browser == "firefox" {
+		launcher, err = pw.Firefox.Launch(playwright.BrowserTypeLaunchOptions{
+			Headless: playwright.Bool(isHidden),
+		})
+	} else if browser == "webkit" { // Changed from safari
+		launcher, err = pw.WebKit.Launch(playwright.BrowserTypeLaunchOptions{
+			Headless: playwright.Bool(isHidden),
+		})
+	} else if browser == "chromium" {
+		launcher, err = pw.Chromium.Launch(playwright.BrowserTypeLaunchOptions{
+			Channel:  playwright.String("chromium"),
+			Headless: playwright.Bool(isHidden),
+		})
+	} else {
+		return nil, fmt.Errorf("browser %s is not compatible", browser)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not launch browser %s: %w", browser, err)
+	}
+
+	return launcher, nil
+}
@@ -0,0 +1,214 @@
+package crawler
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Cookie Source: Represents how a cookie was set.
+type CookieSource string
+
+const (
+	CookieSourceHTTP    CookieSource = "http"    // Set-Cookie response header.
+	CookieSourceScript  CookieSource = "script"  // document.cookie or cookieStore write.
+	CookieSourceUnknown CookieSource = "unknown" // No write was seen, e.g. set before recording started.
+)
+
+// Cookie Write: One observed write of a cookie.
+type cookieWrite struct {
+	name   string
+	domain string // Without leading dot.
+	source CookieSource
+	setBy  string // Response URL for HTTP, script URL for scripts.
+	at     time.Time
+}
+
+// Provenance Recorder: Collects the cookie writes made by scripts in every frame.
+type provenanceRecorder struct {
+	mu     sync.Mutex
+	writes []cookieWrite
+}
+
+// ---- Global Definitions ---- //
+
+// Name of the binding the init script reports script cookie writes to.
+const cookieWriteBinding string = "__privcrawlerCookieWrite"
+
+// Hooks document.cookie and cookieStore.set in every frame and reports each
+// write with the URL of the script that made it, taken from the call stack.
+// Inline scripts report the URL of their document.
+const cookieWriteScript string = `(() => {
+	const scriptURL = () => {
+		const stack = new Error().stack || '';
+		const match = stack.match(/(https?:\/\/[^\s()@]+?):\d+:\d+/);
+		return match ? match[1] : location.href;
+	};
+	const report = (name, domain) => {
+		try {
+			window.` + cookieWriteBinding + `(String(name), domain ? String(domain) : location.hostname, scriptURL());
+		} catch (e) {}
+	};
+
+	const owner = [HTMLDocument.prototype, Document.prototype]
+		.find((proto) => Object.getOwnPropertyDescriptor(proto, 'cookie'));
+	if (owner) {
+		const descriptor = Object.getOwnPropertyDescriptor(owner, 'cookie');
+		Object.defineProperty(owner, 'cookie', {
+			configurable: true,
+			enumerable: descriptor.enumerable,
+			get: function () { return descriptor.get.call(this); },
+			set: function (value) {
+				const text = String(value);
+				const pair = text.split(';')[0];
+				const name = pair.includes('=') ? pair.slice(0, pair.indexOf('=')).trim() : '';
+				const domain = (text.match(/;\s*domain=([^;]+)/i) || [])[1];
+				report(name, domain && domain.trim());
+				return descriptor.set.call(this, value);
+			},
+		});
+	}
+
+	if (window.CookieStore && CookieStore.prototype.set) {
+		const set = CookieStore.prototype.set;
+		CookieStore.prototype.set = function (nameOrOptions, value) {
+			if (typeof nameOrOptions === 'string') {
+				report(nameOrOptions);
+			} else if (nameOrOptions) {
+				report(nameOrOptions.name, nameOrOptions.domain);
+			}
+			return set.apply(this, arguments);
+		};
+	}
+})();`
+
+// ---- Functions ---- //
+
+// Function: New Provenance Recorder
+// Operation: Exposes the cookie write binding and installs the hooking script
+// in the browser context. Must be called before any page is opened.
+// Return: *provenanceRecorder, Error
+func newProvenanceRecorder(browserContext playwright.BrowserContext) (*provenanceRecorder, error) {
+	recorder := &provenanceRecorder{}
+
+	err := browserContext.ExposeBinding(cookieWriteBinding, func(source *playwright.BindingSource, args ...interface{}) interface{} {
+		if len(args) < 3 {
+			return nil
+		}
+		name, _ := args[0].(string)
+		domain, _ := args[1].(string)
+		setBy, _ := args[2].(string)
+
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+		recorder.writes = append(recorder.writes, cookieWrite{
+			name:   name,
+			domain: normalizeCookieDomain(domain),
+			source: CookieSourceScript,
+			setBy:  setBy,
+			at:     time.Now(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not expose cookie binding: %v", err)
+	}
+
+	err = browserContext.AddInitScript(playwright.Script{Content: playwright.String(cookieWriteScript)})
+	if err != nil {
+		return nil, fmt.Errorf("could not add cookie script: %v", err)
+	}
+
+	return recorder, nil
+}
+
+// Function: Sources
+// Operation: Combines the script writes with the Set-Cookie headers of the
+// recorded responses. A cookie written more than once keeps its first write.
+// Return: map of name|domain to the first write
+func (r *provenanceRecorder) sources(requests []NetworkRequest) map[string]cookieWrite {
+	r.mu.Lock()
+	writes := append([]cookieWrite(nil), r.writes...)
+	r.mu.Unlock()
+
+	writes = append(writes, headerCookieWrites(requests)...)
+
+	first := make(map[string]cookieWrite)
+	for _, write := range writes {
+		key := write.name + "|" + write.domain
+		if previous, ok := first[key]; ok && !write.at.Before(previous.at) {
+			continue
+		}
+		first[key] = write
+	}
+
+	return first
+}
+
+// Function: Header Cookie Writes
+// Operation: Reads the Set-Cookie headers of the responses. Playwright joins
+// repeated headers with newlines.
+// Return: []cookieWrite
+func headerCookieWrites(requests []NetworkRequest) []cookieWrite {
+	var writes []cookieWrite
+	for _, request := range requests {
+		header, ok := request.Headers["set-cookie"]
+		if !ok {
+			continue
+		}
+
+		for _, line := range strings.Split(header, "\n") {
+			pair, attributes, _ := strings.Cut(line, ";")
+			name, _, found := strings.Cut(pair, "=")
+			if !found {
+				name = ""
+			}
+
+			domain := request.Host
+			for _, attribute := range strings.Split(attributes, ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(attribute), "=")
+				if strings.EqualFold(key, "domain") && value != "" {
+					domain = value
+				}
+			}
+
+			writes = append(writes, cookieWrite{
+				name:   strings.TrimSpace(name),
+				domain: normalizeCookieDomain(domain),
+				source: CookieSourceHTTP,
+				setBy:  request.URL,
+				at:     request.ReceivedAt,
+			})
+		}
+	}
+
+	return writes
+}
+
+// Function: Normalize Cookie Domain
+// Operation: Lowercases the domain and drops the leading dot, so host-only and
+// domain cookies of the same host match.
+// Return: String
+func normalizeCookieDomain(domain string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// Function: Attribute Cookie
+// Operation: Sets the source of the cookie from the recorded writes.
+// Return: Cookie
+func attributeCookie(cookie Cookie, sources map[string]cookieWrite) Cookie {
+	write, ok := sources[cookie.Name+"|"+normalizeCookieDomain(cookie.Domain)]
+	if !ok {
+		cookie.Source = CookieSourceUnknown
+		return cookie
+	}
+
+	cookie.Source = write.source
+	cookie.SetBy = write.setBy
+	return cookie
+}
@@ -0,0 +1,135 @@
+package crawler
+
+import (
+	"bufio"
+	_ "embed"
+	"net"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/idna"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Public Suffix Rules: The rules of the Public Suffix List, by rule text without
+// the "*." or "!" prefix.
+type publicSuffixRules struct {
+	normal    map[string]bool
+	wildcard  map[string]bool // "*.ck" is stored as "ck".
+	exception map[string]bool // "!www.ck" is stored as "www.ck".
+}
+
+// ---- Global Definitions ---- //
+
+// Public Suffix List snapshot from https://publicsuffix.org/list/public_suffix_list.dat,
+// ICANN and private sections. Update the file to update the rules.
+//
+//go:embed public_suffix_list.dat
+var publicSuffixList string
+
+var (
+	suffixRules     *publicSuffixRules
+	suffixRulesOnce sync.Once
+)
+
+// ---- Functions ---- //
+
+// Function: Load Public Suffix Rules
+// Operation: Parses the embedded list the first time it is needed. IDN rules are
+// stored in punycode, the form hosts of URLs and cookies arrive in.
+// Return: *publicSuffixRules
+func loadPublicSuffixRules() *publicSuffixRules {
+	suffixRulesOnce.Do(func() {
+		rules := &publicSuffixRules{
+			normal:    make(map[string]bool),
+			wildcard:  make(map[string]bool),
+			exception: make(map[string]bool),
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(publicSuffixList))
+		for scanner.Scan() {
+			// A rule is the first word of the line; comments start with "//".
+			fields := strings.Fields(scanner.Text())
+			if len(fields) == 0 || strings.HasPrefix(fields[0], "//") {
+				continue
+			}
+			rule := strings.ToLower(fields[0])
+
+			switch {
+			case strings.HasPrefix(rule, "!"):
+				rules.exception[punycodeRule(rule[1:])] = true
+			case strings.HasPrefix(rule, "*."):
+				rules.wildcard[punycodeRule(rule[2:])] = true
+			default:
+				rules.normal[punycodeRule(rule)] = true
+			}
+		}
+
+		suffixRules = rules
+	})
+
+	return suffixRules
+}
+
+// Function: Punycode Rule
+// Operation: Converts the labels of a rule to their ASCII form, e.g. "公司.cn"
+// gives "xn--55qx5d.cn". ASCII rules are returned unchanged.
+// Return: String, the rule itself if it cannot be converted
+func punycodeRule(rule string) string {
+	ascii, err := idna.ToASCII(rule)
+	if err != nil {
+		return rule
+	}
+	return ascii
+}
+
+// Function: Public Suffix
+// Operation: Finds the public suffix (eTLD) of the host with the list's algorithm:
+// an exception rule wins, otherwise the longest matching rule, otherwise the last label.
+// Return: String
+func publicSuffix(host string) string {
+	rules := loadPublicSuffixRules()
+	labels := strings.Split(host, ".")
+
+	// Walk from the longest candidate to the shortest, so the first match is the longest.
+	for i := range labels {
+		candidate := strings.Join(labels[i:], ".")
+
+		if rules.exception[candidate] {
+			// The exception itself is registrable, its parent is the suffix.
+			return strings.Join(labels[i+1:], ".")
+		}
+		if rules.normal[candidate] {
+			return candidate
+		}
+		if i+1 < len(labels) && rules.wildcard[strings.Join(labels[i+1:], ".")] {
+			return candidate
+		}
+	}
+
+	return labels[len(labels)-1]
+}
+
+// Function: Registrable Domain
+// Operation: Returns the eTLD+1 of a host or cookie domain, e.g. "www.amazon.co.uk"
+// gives "amazon.co.uk". IP addresses, single labels and public suffixes themselves
+// are returned unchanged.
+// Return: String
+func registrableDomain(host string) string {
+	host = strings.TrimSuffix(normalizeCookieDomain(host), ".")
+	if host == "" || net.ParseIP(strings.Trim(host, "[]")) != nil {
+		return host
+	}
+
+	suffix := publicSuffix(host)
+	if suffix == host {
+		return host
+	}
+
+	rest := strings.TrimSuffix(host, "."+suffix)
+	if i := strings.LastIndex(rest, "."); i >= 0 {
+		rest = rest[i+1:]
+	}
+	return rest + "." + suffix
+}
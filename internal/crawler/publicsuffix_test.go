@@ -0,0 +1,79 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistrableDomain(t *testing.T) {
+	tests := []struct {
+		host        string
+		suffix      string
+		registrable string
+	}{
+		{"www.amazon.com", "com", "amazon.com"},
+		{".amazon.com", "com", "amazon.com"},
+		{"WWW.Amazon.COM.", "com", "amazon.com"},
+		{"images-amazon.com", "com", "images-amazon.com"},
+		{"www.amazon.co.uk", "co.uk", "amazon.co.uk"},
+		{".amazon.co.uk", "co.uk", "amazon.co.uk"},
+		{"co.uk", "co.uk", "co.uk"},
+		{"uk", "uk", "uk"},
+
+		// *.ck makes every second level a suffix, except www.ck.
+		{"shop.example.ck", "example.ck", "shop.example.ck"},
+		{"a.shop.example.ck", "example.ck", "shop.example.ck"},
+		{"example.ck", "example.ck", "example.ck"},
+		{"www.ck", "ck", "www.ck"},
+		{"a.www.ck", "ck", "www.ck"},
+
+		// IDN rules match the punycode hosts of URLs and cookies, "公司.cn" and "公司.香港".
+		{"shop.xn--55qx5d.cn", "xn--55qx5d.cn", "shop.xn--55qx5d.cn"},
+		{"www.shop.xn--55qx5d.cn", "xn--55qx5d.cn", "shop.xn--55qx5d.cn"},
+		{"www.shop.xn--55qx5d.xn--j6w193g", "xn--55qx5d.xn--j6w193g", "shop.xn--55qx5d.xn--j6w193g"},
+
+		// Unlisted TLDs fall back to the last label.
+		{"a.b.unlisted", "unlisted", "b.unlisted"},
+		{"localhost", "localhost", "localhost"},
+		{"127.0.0.1", "", "127.0.0.1"},
+		{"[::1]", "", "[::1]"},
+	}
+
+	for _, test := range tests {
+		if test.suffix != "" {
+			host := strings.TrimSuffix(normalizeCookieDomain(test.host), ".")
+			if suffix := publicSuffix(host); suffix != test.suffix {
+				t.Errorf("publicSuffix(%q) = %q, want %q", test.host, suffix, test.suffix)
+			}
+		}
+		if registrable := registrableDomain(test.host); registrable != test.registrable {
+			t.Errorf("registrableDomain(%q) = %q, want %q", test.host, registrable, test.registrable)
+		}
+	}
+}
+
+func TestIsFirstParty(t *testing.T) {
+	tests := []struct {
+		domain string
+		site   string
+		want   bool
+	}{
+		{"www.amazon.com", "https://www.amazon.com/", true},
+		{".amazon.com", "https://www.amazon.com/", true},
+		{"fls-na.amazon.com", "https://www.amazon.com/gp/cart", true},
+		{"images-amazon.com", "https://www.amazon.com/", false},
+		{"amazon.co.uk", "https://www.amazon.com/", false},
+		{".amazon.co.uk", "https://www.amazon.co.uk/", true},
+		{"bbc.co.uk", "https://www.amazon.co.uk/", false},
+		{"a.example.ck", "https://b.example.ck/", false},
+		{"shop.example.ck", "https://www.shop.example.ck/", true},
+		{"www.ck", "https://a.www.ck/", true},
+		{"other.ck", "https://www.ck/", false},
+	}
+
+	for _, test := range tests {
+		if got := isFirstParty(test.domain, test.site); got != test.want {
+			t.Errorf("isFirstParty(%q, %q) = %t, want %t", test.domain, test.site, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Redirect Hop: Represents one response of a navigation, a redirect or the final page.
+type RedirectHop struct {
+	URL          string
+	Host         string
+	Status       int    // 0 if there was no response.
+	Location     string // Empty for the final page.
+	SetCookies   []string
+	IsFirstParty bool
+
+	// Set when the hop is on another site than both ends of the chain and set cookies.
+	Bounce bool
+}
+
+// Redirect Chain: Represents a navigation from the requested URL to the final page.
+// Hops holds every response in order, the final page last.
+type RedirectChain struct {
+	URL      string // Requested URL.
+	FinalURL string
+	Hops     []RedirectHop
+	Error    string
+}
+
+// ---- Global Definitions ---- //
+
+// Most redirects followed outside the browser, as net/http does by default.
+const MAX_REDIRECTS int = 10
+
+// ---- Functions ---- //
+
+// Function: Redirect Chains
+// Operation: Builds the redirect chains of the main frame navigations from the
+// recorded requests. Playwright links each redirect to the request that follows
+// it; collected must be the result of collect, in the same order as r.requests.
+// Navigations that were not redirected are left out.
+// Return: []RedirectChain
+func (r *networkRecorder) redirectChains(collected []NetworkRequest) []RedirectChain {
+	r.mu.Lock()
+	requests := r.requests
+	if len(requests) > len(collected) {
+		requests = requests[:len(collected)]
+	}
+	position := make(map[playwright.Request]int, len(requests))
+	for i, request := range requests {
+		position[request] = i
+	}
+	mainFrame := make(map[playwright.Request]bool, len(r.mainFrame))
+	for request := range r.mainFrame {
+		mainFrame[request] = true
+	}
+	r.mu.Unlock()
+
+	var chains []RedirectChain
+	for _, request := range requests {
+		// Chains are walked back from their last request.
+		if !mainFrame[request] || request.RedirectedFrom() == nil || request.RedirectedTo() != nil {
+			continue
+		}
+
+		var entries []NetworkRequest
+		for hop := request; hop != nil; hop = hop.RedirectedFrom() {
+			i, ok := position[hop]
+			if !ok {
+				break
+			}
+			entries = append([]NetworkRequest{collected[i]}, entries...)
+		}
+
+		chain := RedirectChain{URL: entries[0].URL, FinalURL: entries[len(entries)-1].URL}
+		for _, entry := range entries {
+			chain.Hops = append(chain.Hops, RedirectHop{
+				URL:          entry.URL,
+				Host:         entry.Host,
+				Status:       entry.Status,
+				Location:     entry.Headers["location"],
+				SetCookies:   splitSetCookie(entry.Headers["set-cookie"]),
+				IsFirstParty: entry.IsFirstParty,
+			})
+		}
+		chains = append(chains, markBounces(chain))
+	}
+
+	return chains
+}
+
+// Function: Split Set-Cookie
+// Operation: Splits the Set-Cookie header of a Playwright response, which joins
+// the cookies with newlines.
+// Return: []string
+func splitSetCookie(header string) []string {
+	var cookies []string
+	for _, line := range strings.Split(header, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			cookies = append(cookies, line)
+		}
+	}
+	return cookies
+}
+
+// Function: Fetch Redirect Chain
+// Operation: Fetches the URL outside the browser and records every redirect hop
+// the client follows, with the final page last. Party is decided against siteURL.
+// FetchLandingPage gives the same chain along with the headers and TLS.
+// Return: RedirectChain
+func FetchRedirectChain(ctx context.Context, client *HTTPClient, url, siteURL, userAgent string, verbose *bool) RedirectChain {
+	return FetchLandingPage(ctx, client, url, siteURL, userAgent, nil, verbose).Redirects
+}
+
+// Function: HTTP Hop
+// Operation: Records one response received by the HTTP client.
+// Return: RedirectHop
+func httpHop(response *http.Response, siteURL string) RedirectHop {
+	host := response.Request.URL.Hostname()
+
+	return RedirectHop{
+		URL:          response.Request.URL.String(),
+		Host:         host,
+		Status:       response.StatusCode,
+		Location:     response.Header.Get("Location"),
+		SetCookies:   response.Header.Values("Set-Cookie"),
+		IsFirstParty: isFirstParty(host, siteURL),
+	}
+}
+
+// Function: Mark Bounces
+// Operation: Flags the redirect hops that set cookies on a site that is neither
+// the site the navigation started from nor the one it ended on. The final page
+// is never a bounce.
+// Return: RedirectChain
+func markBounces(chain RedirectChain) RedirectChain {
+	if len(chain.Hops) < 2 {
+		return chain
+	}
+
+	first := chain.Hops[0].URL
+	last := chain.Hops[len(chain.Hops)-1].URL
+	for i := range chain.Hops[:len(chain.Hops)-1] {
+		hop := &chain.Hops[i]
+		if hop.Host == "" || len(hop.SetCookies) == 0 {
+			continue
+		}
+		hop.Bounce = !isFirstParty(hop.Host, first) && !isFirstParty(hop.Host, last)
+	}
+
+	return chain
+}
+
+// Function: Bounce Hosts
+// Operation: Lists the hosts that set cookies while bouncing a navigation, once each.
+// Return: []string, in order of appearance
+func bounceHosts(chains []RedirectChain) []string {
+	var hosts []string
+	listed := make(map[string]bool)
+	for _, chain := range chains {
+		for _, hop := range chain.Hops {
+			if hop.Bounce && !listed[hop.Host] {
+				listed[hop.Host] = true
+				hosts = append(hosts, hop.Host)
+			}
+		}
+	}
+	return hosts
+}
+
+// Function: Set-Cookie Names
+// Operation: Returns the names of the cookies in Set-Cookie headers.
+// Return: []string
+func setCookieNames(headers []string) []string {
+	var names []string
+	for _, header := range headers {
+		name, _, _ := strings.Cut(header, "=")
+		names = append(names, strings.TrimSpace(name))
+	}
+	return names
+}
+
+// Function: Write Redirect Chain
+// Operation: Writes one chain for reports, one hop per line with the cookies it
+// set, marking bounce hops.
+// Return: None
+func writeRedirectChain(report *strings.Builder, chain RedirectChain) {
+	report.WriteString(fmt.Sprintf("\t%s -> %s (%d hops)\n", chain.URL, chain.FinalURL, len(chain.Hops)))
+	for _, hop := range chain.Hops {
+		partyType := "third-party"
+		if hop.IsFirstParty {
+			partyType = "first-party"
+		}
+		line := fmt.Sprintf("\t\t%d %s [%s]", hop.Status, hop.Host, partyType)
+		if len(hop.SetCookies) > 0 {
+			line += fmt.Sprintf(" sets %s", strings.Join(setCookieNames(hop.SetCookies), ", "))
+		}
+		if hop.Bounce {
+			line += " BOUNCE"
+		}
+		report.WriteString(line + "\n")
+	}
+	if chain.Error != "" {
+		report.WriteString(fmt.Sprintf("\t\terror: %s\n", chain.Error))
+	}
+}
+
+// Function: Write Redirects
+// Operation: Writes the redirect chains of the crawl, the landing page fetched
+// outside the browser and the hosts that bounce tracked, for reports.
+// Return: None
+func writeRedirects(report *strings.Builder, privacyMetrics PrivacyMetric) {
+	if len(privacyMetrics.HTTPRedirects.Hops) > 0 || privacyMetrics.HTTPRedirects.Error != "" {
+		report.WriteString("HTTP Redirect Chain:\n")
+		writeRedirectChain(report, privacyMetrics.HTTPRedirects)
+	}
+
+	report.WriteString(fmt.Sprintf("Redirect Chains: %d\n", len(privacyMetrics.Redirects)))
+	for _, chain := range privacyMetrics.Redirects {
+		writeRedirectChain(report, chain)
+	}
+
+	report.WriteString(fmt.Sprintf("Bounce Tracking Hosts: %d\n", len(privacyMetrics.BounceHosts)))
+	for _, host := range privacyMetrics.BounceHosts {
+		report.WriteString(fmt.Sprintf("\t%s\n", host))
+	}
+}
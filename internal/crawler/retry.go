@@ -0,0 +1,233 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Outcome Category: Represents how a crawl ended.
+// OutcomeOK and OutcomeNoCookies are successful crawls, the rest are failures.
+type OutcomeCategory string
+
+const (
+	OutcomeOK         OutcomeCategory = "ok"
+	OutcomeNoCookies  OutcomeCategory = "no-cookies"
+	OutcomeTimeout    OutcomeCategory = "timeout"
+	OutcomeDNS        OutcomeCategory = "dns"
+	OutcomeTLS        OutcomeCategory = "tls"
+	OutcomeConnection OutcomeCategory = "connection"
+	OutcomeHTTP       OutcomeCategory = "http"
+	OutcomeBrowser    OutcomeCategory = "browser"
+	OutcomeCancelled  OutcomeCategory = "cancelled"
+	OutcomeUnknown    OutcomeCategory = "unknown"
+)
+
+// Crawl Outcome: Represents the final result of navigating to the landing page.
+type CrawlOutcome struct {
+	Category OutcomeCategory
+	Attempts int    // Navigation attempts, including retries.
+	Status   int    // HTTP status of the landing page, 0 if there was no response.
+	Error    string // Last navigation error, empty on success.
+}
+
+// Retry Rule: Represents how often and how long to wait before retrying a failure.
+// The wait grows by Multiplier after every attempt.
+type RetryRule struct {
+	Retries    int     `json:"retries"`
+	Backoff    int     `json:"backoff"` // Milliseconds before the first retry.
+	Multiplier float64 `json:"multiplier"`
+}
+
+// Retry Policy: Represents the retry rule for each failure category.
+// Categories without a rule are not retried.
+type RetryPolicy struct {
+	Rules map[OutcomeCategory]RetryRule `json:"rules"`
+}
+
+// ---- Global Definitions ---- //
+
+// Retry File: Location of the pre-configured retry policy.
+const RETRYFILE string = "internal/config/retry.json"
+
+// Error text that identifies each failure category, across Chromium, Firefox and WebKit.
+var dnsErrors = []string{"ERR_NAME_NOT_RESOLVED", "NS_ERROR_UNKNOWN_HOST", "Could not resolve host",
+	"hostname could not be found"}
+var tlsErrors = []string{"ERR_CERT_", "ERR_SSL_", "SSL_ERROR_", "SEC_ERROR_", "NS_ERROR_NET_INADEQUATE_SECURITY",
+	"certificate", "SSL handshake"}
+var connectionErrors = []string{"ERR_CONNECTION_", "ERR_ADDRESS_UNREACHABLE", "ERR_INTERNET_DISCONNECTED",
+	"NS_ERROR_CONNECTION_REFUSED", "NS_ERROR_NET_RESET", "NS_ERROR_NET_INTERRUPT", "NS_ERROR_OFFLINE",
+	"Could not connect", "Connection refused", "connection was lost"}
+
+// ---- Functions ---- //
+
+// Function: Is Failure
+// Operation: Reports whether the outcome is a broken run that aggregates should leave out.
+// Return: bool
+func (outcome CrawlOutcome) IsFailure() bool {
+	return outcome.Category != OutcomeOK && outcome.Category != OutcomeNoCookies
+}
+
+// Function: Default Retry Policy
+// Operation: Returns the policy used when no retry file is given. Transient network
+// failures are retried with backoff; TLS errors and cancellations are not.
+// Return: RetryPolicy
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Rules: map[OutcomeCategory]RetryRule{
+			OutcomeTimeout:    {Retries: 2, Backoff: 2000, Multiplier: 2},
+			OutcomeConnection: {Retries: 2, Backoff: 1000, Multiplier: 2},
+			OutcomeDNS:        {Retries: 1, Backoff: 1000, Multiplier: 1},
+			OutcomeHTTP:       {Retries: 1, Backoff: 2000, Multiplier: 1},
+			OutcomeUnknown:    {Retries: 1, Backoff: 1000, Multiplier: 1},
+		},
+	}
+}
+
+// Function: Read Retry Policy
+// Operation: Reads the retry policy from the JSON file at the given path.
+// Return: RetryPolicy, Error
+func ReadRetryPolicy(path string) (RetryPolicy, error) {
+
+	// Read retry file into data variable.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return RetryPolicy{}, fmt.Errorf("error reading retry file: %v", err)
+	}
+
+	// Parse JSON into data structure.
+	var policy RetryPolicy
+	err = json.Unmarshal(data, &policy)
+	if err != nil {
+		return RetryPolicy{}, fmt.Errorf("error parsing retry file: %v", err)
+	}
+
+	for category, rule := range policy.Rules {
+		if rule.Retries < 0 || rule.Backoff < 0 || rule.Multiplier < 0 {
+			return RetryPolicy{}, fmt.Errorf("retry rule for %s cannot be negative", category)
+		}
+	}
+
+	return policy, nil
+}
+
+// Function: Backoff
+// Operation: Returns the wait in milliseconds before the given retry (1 = first retry).
+// Return: int
+func (rule RetryRule) backoff(retry int) int {
+	wait := float64(rule.Backoff)
+	for i := 1; i < retry; i++ {
+		if rule.Multiplier > 0 {
+			wait *= rule.Multiplier
+		}
+	}
+	return int(wait)
+}
+
+// Function: Classify Navigation
+// Operation: Sorts the result of page.Goto into an outcome category.
+// Return: OutcomeCategory, HTTP status (0 if no response)
+func classifyNavigation(ctx context.Context, response playwright.Response, err error) (OutcomeCategory, int) {
+	status := 0
+	if response != nil {
+		status = response.Status()
+	}
+
+	if ctx.Err() != nil {
+		return OutcomeCancelled, status
+	}
+	if err == nil {
+		if status >= 400 {
+			return OutcomeHTTP, status
+		}
+		return OutcomeOK, status
+	}
+
+	return classifyError(err), status
+}
+
+// Function: Classify Error
+// Operation: Sorts a navigation error into an outcome category by its type and message.
+// Return: OutcomeCategory
+func classifyError(err error) OutcomeCategory {
+	if errors.Is(err, playwright.ErrTimeout) {
+		return OutcomeTimeout
+	}
+
+	message := err.Error()
+	if containsAny(message, dnsErrors) {
+		return OutcomeDNS
+	}
+	if containsAny(message, tlsErrors) {
+		return OutcomeTLS
+	}
+	if containsAny(message, connectionErrors) {
+		return OutcomeConnection
+	}
+	if errors.Is(err, playwright.ErrTargetClosed) {
+		return OutcomeBrowser
+	}
+
+	return OutcomeUnknown
+}
+
+// Function: Contains Any
+// Operation: Checks whether the message contains any of the given parts.
+// Return: bool
+func containsAny(message string, parts []string) bool {
+	for _, part := range parts {
+		if strings.Contains(message, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// Function: Navigate
+// Operation: Goes to the target url, retrying failures as the policy allows.
+// Backoff waits end early when ctx is cancelled.
+// Return: CrawlOutcome of the last attempt
+func navigate(ctx context.Context, page playwright.Page, target string, policy RetryPolicy, verbose bool) CrawlOutcome {
+	outcome := CrawlOutcome{}
+
+	for {
+		outcome.Attempts++
+
+		applyDeadline(ctx, page)
+		response, err := page.Goto(target)
+
+		outcome.Category, outcome.Status = classifyNavigation(ctx, response, err)
+		outcome.Error = ""
+		if err != nil {
+			// Playwright errors carry a multi-line call log, keep the message only.
+			outcome.Error, _, _ = strings.Cut(err.Error(), "\n")
+		} else if outcome.Category == OutcomeHTTP {
+			outcome.Error = fmt.Sprintf("status %d", outcome.Status)
+		}
+
+		if outcome.Category == OutcomeOK || outcome.Category == OutcomeCancelled {
+			return outcome
+		}
+
+		rule, ok := policy.Rules[outcome.Category]
+		if !ok || outcome.Attempts > rule.Retries {
+			return outcome
+		}
+
+		wait := rule.backoff(outcome.Attempts)
+		if verbose {
+			fmt.Printf("Navigation to %s failed (%s), retrying in %dms...\n", target, outcome.Category, wait)
+		}
+		if sleepContext(ctx, wait) != nil {
+			outcome.Category = OutcomeCancelled
+			return outcome
+		}
+	}
+}
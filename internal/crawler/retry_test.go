@@ -0,0 +1,84 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want OutcomeCategory
+	}{
+		{"playwright timeout", fmt.Errorf("%w: page.goto: Timeout 30000ms exceeded", playwright.ErrTimeout), OutcomeTimeout},
+		{"chromium dns", errors.New("page.goto: net::ERR_NAME_NOT_RESOLVED at https://nowhere.invalid/"), OutcomeDNS},
+		{"firefox dns", errors.New("page.goto: NS_ERROR_UNKNOWN_HOST"), OutcomeDNS},
+		{"webkit dns", errors.New("page.goto: A server with the specified hostname could not be found."), OutcomeDNS},
+		{"chromium certificate", errors.New("page.goto: net::ERR_CERT_AUTHORITY_INVALID at https://self-signed.badssl.com/"), OutcomeTLS},
+		{"chromium ssl", errors.New("page.goto: net::ERR_SSL_PROTOCOL_ERROR"), OutcomeTLS},
+		{"firefox certificate", errors.New("page.goto: SEC_ERROR_EXPIRED_CERTIFICATE"), OutcomeTLS},
+		{"webkit certificate", errors.New("page.goto: The certificate for this server is invalid."), OutcomeTLS},
+		{"chromium refused", errors.New("page.goto: net::ERR_CONNECTION_REFUSED at http://localhost:1/"), OutcomeConnection},
+		{"firefox reset", errors.New("page.goto: NS_ERROR_NET_RESET"), OutcomeConnection},
+		{"webkit refused", errors.New("page.goto: Could not connect to server"), OutcomeConnection},
+		{"browser closed", fmt.Errorf("%w: page.goto: Target page, context or browser has been closed", playwright.ErrTargetClosed), OutcomeBrowser},
+		{"message decides before closed", fmt.Errorf("%w: net::ERR_NAME_NOT_RESOLVED", playwright.ErrTargetClosed), OutcomeDNS},
+		{"aborted", errors.New("page.goto: net::ERR_ABORTED at https://example.com/"), OutcomeUnknown},
+	}
+
+	for _, test := range tests {
+		if got := classifyError(test.err); got != test.want {
+			t.Errorf("%s: classifyError(%q) = %s, want %s", test.name, test.err, got, test.want)
+		}
+	}
+}
+
+func TestClassifyNavigation(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want OutcomeCategory
+	}{
+		{"loaded", context.Background(), nil, OutcomeOK},
+		{"failed", context.Background(), errors.New("net::ERR_CONNECTION_RESET"), OutcomeConnection},
+		{"cancelled wins over the error", cancelled, fmt.Errorf("%w: closed", playwright.ErrTargetClosed), OutcomeCancelled},
+		{"cancelled without error", cancelled, nil, OutcomeCancelled},
+	}
+
+	for _, test := range tests {
+		category, status := classifyNavigation(test.ctx, nil, test.err)
+		if category != test.want || status != 0 {
+			t.Errorf("%s: classifyNavigation = %s %d, want %s 0", test.name, category, status, test.want)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		rule  RetryRule
+		retry int
+		want  int
+	}{
+		{RetryRule{Backoff: 1000, Multiplier: 2}, 1, 1000},
+		{RetryRule{Backoff: 1000, Multiplier: 2}, 2, 2000},
+		{RetryRule{Backoff: 1000, Multiplier: 2}, 3, 4000},
+		{RetryRule{Backoff: 1500, Multiplier: 1.5}, 3, 3375},
+		{RetryRule{Backoff: 1000, Multiplier: 0}, 3, 1000},
+		{RetryRule{Backoff: 0, Multiplier: 2}, 2, 0},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.backoff(test.retry); got != test.want {
+			t.Errorf("%+v backoff(%d) = %d, want %d", test.rule, test.retry, got, test.want)
+		}
+	}
+}
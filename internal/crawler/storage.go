@@ -0,0 +1,137 @@
+package crawler
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Storage Item: Represents one localStorage or sessionStorage key.
+type StorageItem struct {
+	Key  string `json:"key"`
+	Size int    `json:"size"` // Length of the value in characters.
+}
+
+// IndexedDB Database: Represents one IndexedDB database and its object stores.
+type IndexedDBDatabase struct {
+	Name    string   `json:"name"`
+	Version int      `json:"version"`
+	Stores  []string `json:"stores"`
+}
+
+// Origin Storage: Represents the web storage of one origin at the end of a visit.
+type OriginStorage struct {
+	Origin         string              `json:"origin"`
+	IsFirstParty   bool                `json:"-"`
+	LocalStorage   []StorageItem       `json:"local"`
+	SessionStorage []StorageItem       `json:"session"`
+	IndexedDB      []IndexedDBDatabase `json:"indexedDB"`
+}
+
+// ---- Global Definitions ---- //
+
+// Reads the storage of the frame's origin. Frames that may not touch storage,
+// such as sandboxed frames, return what they can.
+const storageScript string = `async () => {
+	const items = (storage) => {
+		const out = [];
+		for (let i = 0; i < storage.length; i++) {
+			const key = storage.key(i);
+			out.push({ key: key, size: (storage.getItem(key) || '').length });
+		}
+		return out;
+	};
+	const result = { origin: location.origin, local: [], session: [], indexedDB: [] };
+	try { result.local = items(window.localStorage); } catch (e) {}
+	try { result.session = items(window.sessionStorage); } catch (e) {}
+	try {
+		if (indexedDB.databases) {
+			for (const info of await indexedDB.databases()) {
+				const stores = await new Promise((resolve) => {
+					const request = indexedDB.open(info.name);
+					request.onsuccess = () => {
+						const names = Array.from(request.result.objectStoreNames);
+						request.result.close();
+						resolve(names);
+					};
+					request.onerror = () => resolve([]);
+					request.onblocked = () => resolve([]);
+				});
+				result.indexedDB.push({ name: info.name, version: info.version || 0, stores: stores });
+			}
+		}
+	} catch (e) {}
+	return result;
+}`
+
+// ---- Functions ---- //
+
+// Function: Capture Storage
+// Operation: Reads localStorage, sessionStorage and IndexedDB of every frame on the
+// page and merges them into storage by origin. Later captures replace earlier ones.
+// Return: None
+func captureStorage(page playwright.Page, siteURL string, storage map[string]OriginStorage) {
+	for _, frame := range page.Frames() {
+		result, err := frame.Evaluate(storageScript)
+		if err != nil {
+			continue
+		}
+
+		// Round-trip through JSON to map the result onto OriginStorage.
+		data, err := json.Marshal(result)
+		if err != nil {
+			continue
+		}
+		var origin OriginStorage
+		if json.Unmarshal(data, &origin) != nil || origin.Origin == "" || origin.Origin == "null" {
+			continue
+		}
+
+		origin.IsFirstParty = isFirstParty(hostOf(origin.Origin), siteURL)
+		storage[origin.Origin] = origin
+	}
+}
+
+// Function: Storage List
+// Operation: Returns the captured storage sorted by origin.
+// Return: []OriginStorage
+func storageList(storage map[string]OriginStorage) []OriginStorage {
+	origins := make([]OriginStorage, 0, len(storage))
+	for _, origin := range storage {
+		origins = append(origins, origin)
+	}
+	sort.Slice(origins, func(i, j int) bool {
+		return origins[i].Origin < origins[j].Origin
+	})
+
+	return origins
+}
+
+// Function: Add Storage To Metric
+// Operation: Adds the key, database and origin counts of the captured storage.
+// Return: None
+func addStorageToMetric(privacyMetrics *PrivacyMetric, storage []OriginStorage) {
+	for _, origin := range storage {
+		if len(origin.LocalStorage) == 0 && len(origin.SessionStorage) == 0 && len(origin.IndexedDB) == 0 {
+			continue
+		}
+
+		privacyMetrics.StorageOrigins = append(privacyMetrics.StorageOrigins, origin)
+		if !origin.IsFirstParty {
+			privacyMetrics.ThirdPartyStorageOrigins++
+		}
+
+		privacyMetrics.LocalStorageKeys += len(origin.LocalStorage)
+		privacyMetrics.SessionStorageKeys += len(origin.SessionStorage)
+		privacyMetrics.IndexedDBDatabases += len(origin.IndexedDB)
+		for _, item := range origin.LocalStorage {
+			privacyMetrics.LocalStorageSize += item.Size
+		}
+		for _, item := range origin.SessionStorage {
+			privacyMetrics.SessionStorageSize += item.Size
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package crawler
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Timeline Point: Represents one cookie snapshot, taken At a time after navigation start.
+type TimelinePoint struct {
+	At         time.Duration
+	Total      int
+	FirstParty int
+	ThirdParty int
+}
+
+// Cookie Timeline: Records when each cookie first appeared and last changed
+// during a visit, and the cookie count at every snapshot.
+type cookieTimeline struct {
+	mu          sync.Mutex
+	siteURL     string
+	start       time.Time
+	firstSeen   map[string]time.Duration
+	lastChanged map[string]time.Duration
+	values      map[string]string
+	points      []TimelinePoint
+}
+
+// ---- Global Definitions ---- //
+
+// Timeline File: Cookies-over-time series for every crawl, one row per snapshot.
+const TIMELINEFILE string = "TIMELINE.csv"
+
+// ---- Functions ---- //
+
+// Function: New Cookie Timeline
+// Operation: Starts a timeline at the current time, which should be navigation start.
+// Return: *cookieTimeline
+func newCookieTimeline(siteURL string) *cookieTimeline {
+	return &cookieTimeline{
+		siteURL:     siteURL,
+		start:       time.Now(),
+		firstSeen:   make(map[string]time.Duration),
+		lastChanged: make(map[string]time.Duration),
+		values:      make(map[string]string),
+	}
+}
+
+// Function: Record
+// Operation: Adds a snapshot of the context cookies to the timeline.
+// Return: None
+func (t *cookieTimeline) record(cookies []playwright.Cookie) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	at := time.Since(t.start)
+	point := TimelinePoint{At: at, Total: len(cookies)}
+
+	for _, c := range cookies {
+		key := c.Name + "|" + c.Domain + "|" + c.Path
+
+		if _, ok := t.firstSeen[key]; !ok {
+			t.firstSeen[key] = at
+			t.lastChanged[key] = at
+		} else if t.values[key] != c.Value {
+			t.lastChanged[key] = at
+		}
+		t.values[key] = c.Value
+
+		if isFirstParty(c.Domain, t.siteURL) {
+			point.FirstParty++
+		} else {
+			point.ThirdParty++
+		}
+	}
+
+	t.points = append(t.points, point)
+}
+
+// Function: Poll
+// Operation: Takes a snapshot every interval milliseconds until ctx is done
+// or the returned stop function is called.
+// Return: A function that stops polling and waits for the last snapshot
+func (t *cookieTimeline) poll(ctx context.Context, browserContext playwright.BrowserContext, interval int) func() {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(time.Duration(interval) * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				cookies, err := browserContext.Cookies()
+				if err == nil {
+					t.record(cookies)
+				}
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// Function: Stamp
+// Operation: Copies the first-seen and last-changed times onto the cookie.
+// Return: Cookie
+func (t *cookieTimeline) stamp(cookie Cookie) Cookie {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := cookieKey(cookie)
+	cookie.FirstSeenAt = t.firstSeen[key]
+	cookie.LastChangedAt = t.lastChanged[key]
+
+	return cookie
+}
+
+// Function: Series
+// Operation: Returns a copy of the snapshots taken so far.
+// Return: []TimelinePoint
+func (t *cookieTimeline) series() []TimelinePoint {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]TimelinePoint(nil), t.points...)
+}
+
+// Function: Append Timeline To File
+// Operation: Appends the cookies-over-time series of one crawl to TIMELINE.csv,
+// writing the header when the file is new.
+// Return: Error
+func AppendTimelineToFile(timeline []TimelinePoint, url, browser string) error {
+	if len(timeline) == 0 {
+		return nil
+	}
+
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	rows := make([][]string, 0, len(timeline))
+	for _, point := range timeline {
+		rows = append(rows, []string{timestamp, url, browser, strconv.FormatInt(point.At.Milliseconds(), 10),
+			strconv.Itoa(point.Total), strconv.Itoa(point.FirstParty), strconv.Itoa(point.ThirdParty)})
+	}
+
+	return appendCSV(TIMELINEFILE, []string{"timestamp", "url", "browser", "ms", "total", "first_party", "third_party"}, rows)
+}
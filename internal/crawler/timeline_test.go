@@ -0,0 +1,43 @@
+package crawler
+
+import (
+	"encoding/csv"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAppendTimelineToFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	timeline := []TimelinePoint{
+		{At: 250 * time.Millisecond, Total: 3, FirstParty: 2, ThirdParty: 1},
+		{At: 1500 * time.Millisecond, Total: 7, FirstParty: 3, ThirdParty: 4},
+	}
+	for range 2 {
+		if err := AppendTimelineToFile(timeline, "https://example.com/search?q=a,b", "firefox"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file, err := os.Open(TIMELINEFILE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("reading back: %v", err)
+	}
+	if len(records) != 5 {
+		t.Fatalf("%d records, want a header and 4 rows", len(records))
+	}
+	if records[0][1] != "url" {
+		t.Errorf("header %v", records[0])
+	}
+	if want := []string{"https://example.com/search?q=a,b", "firefox", "1500", "7", "3", "4"}; !reflect.DeepEqual(records[2][1:], want) {
+		t.Errorf("row %v, want %v", records[2][1:], want)
+	}
+}
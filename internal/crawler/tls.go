@@ -0,0 +1,343 @@
+package crawler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// TLS Info: Represents the transport security of the landing page. Error is set
+// if the page could not be fetched; Weaknesses lists what the report should flag.
+type TLSInfo struct {
+	URL   string // Final URL, after redirects.
+	HTTPS bool
+
+	Version     string
+	CipherSuite string
+	ALPN        string
+
+	// Leaf certificate, and why it did not verify for the host, if it did not.
+	Issuer      string
+	Subject     string
+	NotBefore   time.Time
+	NotAfter    time.Time
+	SANs        []string
+	VerifyError string
+
+	// HSTS header of the response and whether the host is on the preload list.
+	HSTS        string
+	HSTSPreload bool // Header asks for preloading.
+	Preloaded   PreloadStatus
+	PreloadList string // Version of the list used.
+	Weaknesses  []string
+	Error       string
+}
+
+// Preload Status: Represents whether the host is on the HSTS preload list. It is
+// unknown when there is no list, or when the list is a seed that cannot rule a host out.
+type PreloadStatus string
+
+const (
+	PreloadListed    PreloadStatus = "preloaded"
+	PreloadNotListed PreloadStatus = "not preloaded"
+	PreloadUnknown   PreloadStatus = "unknown"
+)
+
+// HSTS Preload Entry: Represents one entry of the preload list, in the format of
+// Chromium's transport_security_state_static.json.
+type HSTSPreloadEntry struct {
+	Name              string `json:"name"`
+	IncludeSubdomains bool   `json:"include_subdomains"`
+	Mode              string `json:"mode"` // "force-https"; entries without a mode only pin keys.
+}
+
+// HSTS Preload List: Represents the structure of the preload list file.
+type HSTSPreloadList struct {
+	Version string             `json:"version"`
+	Entries []HSTSPreloadEntry `json:"entries"`
+
+	// Set for a partial list, such as the seed shipped with the crawler. Hosts
+	// not on a seed are reported as unknown rather than not preloaded.
+	Seed bool `json:"seed"`
+
+	// Forced host to whether its subdomains are included.
+	hosts map[string]bool
+}
+
+// ---- Global Definitions ---- //
+
+// HSTS Preload File: Location of the HSTS preload list.
+const HSTSPRELOADFILE string = "internal/config/hsts_preload.json"
+
+// Certificates expiring within this many days are flagged.
+const CERT_EXPIRY_WARNING_DAYS int = 30
+
+// Smallest RSA key, in bits, that is not flagged.
+const MIN_RSA_KEY_BITS int = 2048
+
+// Certificate signature algorithms that can be forged.
+var weakSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.MD2WithRSA:    true,
+	x509.MD5WithRSA:    true,
+	x509.SHA1WithRSA:   true,
+	x509.DSAWithSHA1:   true,
+	x509.ECDSAWithSHA1: true,
+}
+
+// ---- Functions ---- //
+
+// Function: Read HSTS Preload List
+// Operation: Reads the preload list from the JSON file at the given path. Lines
+// starting with "//" are comments, as in Chromium's file.
+// Return: *HSTSPreloadList, Error
+func ReadHSTSPreloadList(path string) (*HSTSPreloadList, error) {
+
+	// Read preload file into data variable.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading HSTS preload list: %v", err)
+	}
+
+	// Drop comment lines, which JSON does not allow.
+	var content bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if !strings.HasPrefix(strings.TrimSpace(scanner.Text()), "//") {
+			content.Write(scanner.Bytes())
+			content.WriteByte('\n')
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading HSTS preload list: %v", err)
+	}
+
+	// Parse JSON into data structure.
+	var list HSTSPreloadList
+	err = json.Unmarshal(content.Bytes(), &list)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing HSTS preload list: %v", err)
+	}
+
+	list.hosts = make(map[string]bool)
+	for _, entry := range list.Entries {
+		if entry.Mode == "force-https" {
+			list.hosts[normalizeCookieDomain(entry.Name)] = entry.IncludeSubdomains
+		}
+	}
+
+	return &list, nil
+}
+
+// Function: Is Preloaded
+// Operation: Reports whether browsers force HTTPS for the host from the preload
+// list, by its own entry or a parent entry that includes subdomains.
+// Return: bool
+func (list *HSTSPreloadList) IsPreloaded(host string) bool {
+	if list == nil {
+		return false
+	}
+
+	host = normalizeCookieDomain(host)
+	if _, ok := list.hosts[host]; ok {
+		return true
+	}
+	for {
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			return false
+		}
+		if includeSubdomains, ok := list.hosts[parent]; ok && includeSubdomains {
+			return true
+		}
+		host = parent
+	}
+}
+
+// Function: Status
+// Operation: Reports whether the host is preloaded. Hosts not on the list are
+// unknown if there is no list or the list is a seed.
+// Return: PreloadStatus
+func (list *HSTSPreloadList) Status(host string) PreloadStatus {
+	switch {
+	case list.IsPreloaded(host):
+		return PreloadListed
+	case list == nil || list.Seed:
+		return PreloadUnknown
+	default:
+		return PreloadNotListed
+	}
+}
+
+// Function: Version Of
+// Operation: Returns the version of the preload list for reports.
+// Return: String
+func (list *HSTSPreloadList) versionOf() string {
+	if list == nil {
+		return "none"
+	}
+	return list.Version
+}
+
+// Function: Fetch TLS Info
+// Operation: Fetches the URL and records the negotiated TLS connection, the leaf
+// certificate and HSTS preload status. FetchLandingPage gives the same information
+// along with the headers and redirects.
+// Return: TLSInfo
+func FetchTLSInfo(ctx context.Context, client *HTTPClient, url, userAgent string, preload *HSTSPreloadList, verbose *bool) TLSInfo {
+	return FetchLandingPage(ctx, client, url, url, userAgent, preload, verbose).TLS
+}
+
+// Function: TLS Info Of
+// Operation: Records the TLS connection and certificate of the final response of
+// the landing page. The certificate is verified here rather than by the client,
+// so sites with invalid certificates are still inspected.
+// Return: TLSInfo
+func tlsInfoOf(response *http.Response, preload *HSTSPreloadList) TLSInfo {
+	info := TLSInfo{PreloadList: preload.versionOf()}
+
+	host := response.Request.URL.Hostname()
+	info.URL = response.Request.URL.String()
+	info.HSTS = response.Header.Get("Strict-Transport-Security")
+	info.Preloaded = preload.Status(host)
+
+	if response.TLS == nil {
+		info.Weaknesses = append(info.Weaknesses, "site is not served over HTTPS")
+		return info
+	}
+	info.HTTPS = true
+
+	state := response.TLS
+	info.Version = tls.VersionName(state.Version)
+	info.CipherSuite = tls.CipherSuiteName(state.CipherSuite)
+	info.ALPN = state.NegotiatedProtocol
+
+	if state.Version < tls.VersionTLS12 {
+		info.Weaknesses = append(info.Weaknesses, fmt.Sprintf("%s is deprecated", info.Version))
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		if suite.ID == state.CipherSuite {
+			info.Weaknesses = append(info.Weaknesses, fmt.Sprintf("insecure cipher suite %s", info.CipherSuite))
+		}
+	}
+
+	inspectCertificate(&info, state.PeerCertificates, host, time.Now())
+
+	// HSTS only counts on HTTPS responses. Browsers force HTTPS for preloaded hosts without it.
+	maxAge, includeSubDomains, preloadRequested := parseHSTS(info.HSTS)
+	info.HSTSPreload = preloadRequested
+	switch {
+	case (info.HSTS == "" || maxAge <= 0) && info.Preloaded != PreloadListed:
+		info.Weaknesses = append(info.Weaknesses, "no HSTS, visits can be downgraded to HTTP")
+	case preloadRequested && info.Preloaded != PreloadListed && (maxAge < HSTS_MIN_MAX_AGE || !includeSubDomains):
+		info.Weaknesses = append(info.Weaknesses, "HSTS preload requested but the header does not qualify")
+	}
+
+	return info
+}
+
+// Function: Inspect Certificate
+// Operation: Records the leaf certificate, verifies the chain for the host and
+// flags expired, expiring, weakly signed or small-key certificates.
+// Return: None
+func inspectCertificate(info *TLSInfo, certificates []*x509.Certificate, host string, now time.Time) {
+	if len(certificates) == 0 {
+		info.Weaknesses = append(info.Weaknesses, "no certificate")
+		return
+	}
+
+	leaf := certificates[0]
+	info.Issuer = certificateName(leaf.Issuer.Organization, leaf.Issuer.CommonName)
+	info.Subject = certificateName(leaf.Subject.Organization, leaf.Subject.CommonName)
+	info.NotBefore = leaf.NotBefore
+	info.NotAfter = leaf.NotAfter
+	info.SANs = append(info.SANs, leaf.DNSNames...)
+	for _, ip := range leaf.IPAddresses {
+		info.SANs = append(info.SANs, ip.String())
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	_, err := leaf.Verify(x509.VerifyOptions{DNSName: host, Intermediates: intermediates, CurrentTime: now})
+	if err != nil {
+		info.VerifyError = err.Error()
+		info.Weaknesses = append(info.Weaknesses, fmt.Sprintf("certificate does not verify: %v", err))
+	}
+
+	switch {
+	case now.After(leaf.NotAfter):
+		info.Weaknesses = append(info.Weaknesses, "certificate has expired")
+	case now.Before(leaf.NotBefore):
+		info.Weaknesses = append(info.Weaknesses, "certificate is not valid yet")
+	case leaf.NotAfter.Sub(now) < time.Duration(CERT_EXPIRY_WARNING_DAYS)*24*time.Hour:
+		info.Weaknesses = append(info.Weaknesses, fmt.Sprintf("certificate expires within %d days", CERT_EXPIRY_WARNING_DAYS))
+	}
+
+	if weakSignatureAlgorithms[leaf.SignatureAlgorithm] {
+		info.Weaknesses = append(info.Weaknesses, fmt.Sprintf("weak certificate signature %s", leaf.SignatureAlgorithm))
+	}
+	if key, ok := leaf.PublicKey.(*rsa.PublicKey); ok && key.N.BitLen() < MIN_RSA_KEY_BITS {
+		info.Weaknesses = append(info.Weaknesses, fmt.Sprintf("%d-bit RSA key", key.N.BitLen()))
+	}
+}
+
+// Function: Certificate Name
+// Operation: Names a certificate subject or issuer by organization and common name.
+// Return: String
+func certificateName(organization []string, commonName string) string {
+	if len(organization) == 0 {
+		return commonName
+	}
+	if commonName == "" {
+		return organization[0]
+	}
+	return fmt.Sprintf("%s (%s)", organization[0], commonName)
+}
+
+// Function: Write TLS Info
+// Operation: Writes the TLS connection, certificate and weaknesses for reports.
+// Return: None
+func writeTLSInfo(report *strings.Builder, info TLSInfo) {
+	if info.Error != "" {
+		report.WriteString(fmt.Sprintf("TLS Error: %s\n", info.Error))
+		return
+	}
+	if info.URL == "" {
+		report.WriteString("TLS: not inspected\n")
+		return
+	}
+
+	if info.HTTPS {
+		report.WriteString(fmt.Sprintf("TLS Version: %s\n", info.Version))
+		report.WriteString(fmt.Sprintf("TLS Cipher Suite: %s\n", info.CipherSuite))
+		if info.ALPN != "" {
+			report.WriteString(fmt.Sprintf("TLS ALPN: %s\n", info.ALPN))
+		}
+		report.WriteString(fmt.Sprintf("Certificate Issuer: %s\n", info.Issuer))
+		report.WriteString(fmt.Sprintf("Certificate Subject: %s\n", info.Subject))
+		report.WriteString(fmt.Sprintf("Certificate Valid: %s to %s\n", info.NotBefore.Format("2006-01-02"),
+			info.NotAfter.Format("2006-01-02")))
+		report.WriteString(fmt.Sprintf("Certificate SANs: %d\n", len(info.SANs)))
+		for _, name := range info.SANs {
+			report.WriteString(fmt.Sprintf("\t%s\n", name))
+		}
+	}
+	report.WriteString(fmt.Sprintf("HSTS Preloaded: %s, requested %t (list %s)\n", info.Preloaded, info.HSTSPreload, info.PreloadList))
+	report.WriteString(fmt.Sprintf("Transport Weaknesses: %d\n", len(info.Weaknesses)))
+	for _, weakness := range info.Weaknesses {
+		report.WriteString(fmt.Sprintf("\t%s\n", weakness))
+	}
+}
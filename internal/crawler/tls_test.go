@@ -0,0 +1,209 @@
+package crawler
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Function: Self-Signed Certificate
+// Operation: Creates a self-signed ECDSA certificate for 127.0.0.1, valid in the given window.
+// Return: tls.Certificate
+func selfSignedCertificate(t *testing.T, notBefore, notAfter time.Time) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{Organization: []string{"Test"}, CommonName: "127.0.0.1"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("could not create certificate: %v", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// Function: Has Weakness
+// Operation: Reports whether a weakness of the TLS info starts with the prefix.
+// Return: bool
+func hasWeakness(info TLSInfo, prefix string) bool {
+	for _, weakness := range info.Weaknesses {
+		if strings.HasPrefix(weakness, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFetchTLSInfo(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		tlsConfig *tls.Config // nil uses the httptest certificate and defaults.
+		hsts      string
+
+		version      string
+		cipherSuite  string // Checked when set.
+		hstsPreload  bool
+		weaknesses   []string
+		noWeaknesses []string
+	}{
+		{
+			name:         "default server with preload header",
+			hsts:         "max-age=63072000; includeSubDomains; preload",
+			version:      "TLS 1.3",
+			hstsPreload:  true,
+			weaknesses:   []string{"certificate does not verify"},
+			noWeaknesses: []string{"no HSTS", "HSTS preload requested", "certificate has expired"},
+		},
+		{
+			name: "TLS 1.2 with a fixed cipher suite and no HSTS",
+			tlsConfig: &tls.Config{
+				MaxVersion:   tls.VersionTLS12,
+				CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+				Certificates: []tls.Certificate{selfSignedCertificate(t, now.Add(-time.Hour), now.Add(365*24*time.Hour))},
+			},
+			version:      "TLS 1.2",
+			cipherSuite:  "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+			weaknesses:   []string{"certificate does not verify", "no HSTS"},
+			noWeaknesses: []string{"certificate has expired", "certificate expires within"},
+		},
+		{
+			name: "expired self-signed certificate",
+			tlsConfig: &tls.Config{
+				Certificates: []tls.Certificate{selfSignedCertificate(t, now.Add(-48*time.Hour), now.Add(-24*time.Hour))},
+			},
+			hsts:        "max-age=300; preload",
+			version:     "TLS 1.3",
+			hstsPreload: true,
+			weaknesses:  []string{"certificate does not verify", "certificate has expired", "HSTS preload requested"},
+		},
+		{
+			name: "certificate about to expire",
+			tlsConfig: &tls.Config{
+				Certificates: []tls.Certificate{selfSignedCertificate(t, now.Add(-time.Hour), now.Add(24*time.Hour))},
+			},
+			hsts:         "max-age=31536000",
+			version:      "TLS 1.3",
+			weaknesses:   []string{"certificate expires within"},
+			noWeaknesses: []string{"certificate has expired", "no HSTS"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if test.hsts != "" {
+					w.Header().Set("Strict-Transport-Security", test.hsts)
+				}
+			}))
+			if test.tlsConfig != nil {
+				server.TLS = test.tlsConfig
+			}
+			server.StartTLS()
+			defer server.Close()
+
+			verbose := false
+			info := FetchTLSInfo(context.Background(), nil, server.URL, "", nil, &verbose)
+			if info.Error != "" {
+				t.Fatalf("FetchTLSInfo error: %s", info.Error)
+			}
+			if !info.HTTPS || info.Version != test.version {
+				t.Errorf("HTTPS %t version %q, want %q", info.HTTPS, info.Version, test.version)
+			}
+			if info.CipherSuite == "" || (test.cipherSuite != "" && info.CipherSuite != test.cipherSuite) {
+				t.Errorf("cipher suite %q, want %q", info.CipherSuite, test.cipherSuite)
+			}
+			if info.HSTS != test.hsts || info.HSTSPreload != test.hstsPreload {
+				t.Errorf("HSTS %q preload %t, want %q %t", info.HSTS, info.HSTSPreload, test.hsts, test.hstsPreload)
+			}
+			if info.Preloaded != PreloadUnknown {
+				t.Errorf("preloaded %q without a list, want %q", info.Preloaded, PreloadUnknown)
+			}
+			if info.VerifyError == "" {
+				t.Errorf("self-signed certificate verified")
+			}
+			for _, weakness := range test.weaknesses {
+				if !hasWeakness(info, weakness) {
+					t.Errorf("weakness %q missing from %q", weakness, info.Weaknesses)
+				}
+			}
+			for _, weakness := range test.noWeaknesses {
+				if hasWeakness(info, weakness) {
+					t.Errorf("unexpected weakness %q in %q", weakness, info.Weaknesses)
+				}
+			}
+		})
+	}
+}
+
+func TestHSTSPreloadStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hsts_preload.json")
+	content := `// Comment lines are dropped.
+{
+  "version": "test",
+  "entries": [
+    { "name": "dev", "include_subdomains": true, "mode": "force-https" },
+    { "name": "www.example.com", "include_subdomains": false, "mode": "force-https" },
+    { "name": "pinned.example.com", "include_subdomains": true }
+  ]
+}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	list, err := ReadHSTSPreloadList(path)
+	if err != nil {
+		t.Fatalf("ReadHSTSPreloadList: %v", err)
+	}
+	seed := *list
+	seed.Seed = true
+
+	tests := []struct {
+		host       string
+		list, seed PreloadStatus
+	}{
+		{"web.dev", PreloadListed, PreloadListed},
+		{"WWW.Example.com", PreloadListed, PreloadListed},
+		{"sub.www.example.com", PreloadNotListed, PreloadUnknown},
+		{"example.com", PreloadNotListed, PreloadUnknown},
+		{"pinned.example.com", PreloadNotListed, PreloadUnknown},
+		{"amazon.com", PreloadNotListed, PreloadUnknown},
+	}
+	for _, test := range tests {
+		if status := list.Status(test.host); status != test.list {
+			t.Errorf("Status(%q) = %q, want %q", test.host, status, test.list)
+		}
+		if status := seed.Status(test.host); status != test.seed {
+			t.Errorf("seed Status(%q) = %q, want %q", test.host, status, test.seed)
+		}
+	}
+
+	var none *HSTSPreloadList
+	if status := none.Status("web.dev"); status != PreloadUnknown {
+		t.Errorf("Status without a list = %q, want %q", status, PreloadUnknown)
+	}
+}
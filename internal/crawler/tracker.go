@@ -0,0 +1,252 @@
+package crawler
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Tracker Category: Represents what a tracker host is used for.
+type TrackerCategory string
+
+const (
+	TrackerAdvertising    TrackerCategory = "advertising"
+	TrackerAnalytics      TrackerCategory = "analytics"
+	TrackerSocial         TrackerCategory = "social"
+	TrackerFingerprinting TrackerCategory = "fingerprinting"
+	TrackerContent        TrackerCategory = "content" // Embedded content that tracks, e.g. video and comment widgets.
+	TrackerCDN            TrackerCategory = "cdn"
+)
+
+// Tracker List Format: Represents the file format of a filter list.
+type TrackerListFormat string
+
+const (
+	// Disconnect services.json, categories of companies and their domains.
+	FormatDisconnect TrackerListFormat = "disconnect"
+	// EasyList/EasyPrivacy filter rules, only domain rules (||host^) are used.
+	FormatEasyList TrackerListFormat = "easylist"
+)
+
+// Tracker List File: Represents one filter list in the tracker file.
+// Category is required for easylist files, which have no categories of their own.
+type TrackerListFile struct {
+	File     string            `json:"file"`
+	Format   TrackerListFormat `json:"format"`
+	Category TrackerCategory   `json:"category"`
+}
+
+// Tracker Config: Represents the structure of the tracker file.
+type TrackerConfig struct {
+	Version string            `json:"version"`
+	Lists   []TrackerListFile `json:"lists"`
+}
+
+// Tracker Lists: The loaded filter lists, host to category.
+type TrackerLists struct {
+	// Version of the tracker file and a hash of the list contents, written
+	// with every report so results can be tied to the lists that produced them.
+	Version string
+	domains map[string]TrackerCategory
+}
+
+// ---- Global Definitions ---- //
+
+// Tracker File: Location of the filter list configuration.
+const TRACKERFILE string = "internal/config/trackers.json"
+
+// Disconnect categories and the tracker category they are counted as.
+// Categories that are not listed are ignored.
+var disconnectCategories = map[string]TrackerCategory{
+	"Advertising":            TrackerAdvertising,
+	"Analytics":              TrackerAnalytics,
+	"Social":                 TrackerSocial,
+	"FingerprintingInvasive": TrackerFingerprinting,
+	"FingerprintingGeneral":  TrackerFingerprinting,
+	"Content":                TrackerContent,
+}
+
+// Order in which categories are reported.
+var trackerCategories = []TrackerCategory{TrackerAdvertising, TrackerAnalytics, TrackerSocial,
+	TrackerFingerprinting, TrackerContent, TrackerCDN}
+
+// ---- Functions ---- //
+
+// Function: Read Tracker Lists
+// Operation: Reads the tracker file at the given path and every filter list it names.
+// When lists disagree on a host, the list named first wins.
+// Return: *TrackerLists, Error
+func ReadTrackerLists(path string) (*TrackerLists, error) {
+
+	// Read tracker file into data variable.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading tracker file: %v", err)
+	}
+
+	// Parse JSON into data structure.
+	var config TrackerConfig
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing tracker file: %v", err)
+	}
+
+	lists := &TrackerLists{domains: make(map[string]TrackerCategory)}
+	hash := sha256.New()
+
+	for _, list := range config.Lists {
+		content, err := os.ReadFile(list.File)
+		if err != nil {
+			return nil, fmt.Errorf("error reading tracker list: %v", err)
+		}
+		hash.Write(content)
+
+		switch list.Format {
+		case FormatDisconnect:
+			err = lists.addDisconnect(content)
+		case FormatEasyList:
+			if list.Category == "" {
+				return nil, fmt.Errorf("tracker list %s needs a category", list.File)
+			}
+			lists.addEasyList(content, list.Category)
+		default:
+			return nil, fmt.Errorf("tracker list %s has unsupported format %s", list.File, list.Format)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing tracker list %s: %v", list.File, err)
+		}
+	}
+
+	lists.Version = fmt.Sprintf("%s (%s)", config.Version, hex.EncodeToString(hash.Sum(nil))[:12])
+	return lists, nil
+}
+
+// Function: Add Disconnect
+// Operation: Adds the domains of a Disconnect services.json list.
+// The file maps category -> [{company: {homepage: [domains]}}].
+// Return: Error
+func (lists *TrackerLists) addDisconnect(content []byte) error {
+	var services struct {
+		Categories map[string][]map[string]map[string]json.RawMessage `json:"categories"`
+	}
+	err := json.Unmarshal(content, &services)
+	if err != nil {
+		return err
+	}
+
+	for name, companies := range services.Categories {
+		category, ok := disconnectCategories[name]
+		if !ok {
+			continue
+		}
+
+		for _, company := range companies {
+			for _, properties := range company {
+				for _, raw := range properties {
+					// Besides homepage -> domains, entries may carry flags such as "dnt": "eff".
+					var domains []string
+					if json.Unmarshal(raw, &domains) != nil {
+						continue
+					}
+					for _, domain := range domains {
+						lists.add(domain, category)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Function: Add EasyList
+// Operation: Adds the hosts of the domain rules (||host^) in an EasyList format list.
+// Comments, exceptions, element hiding and path rules are skipped.
+// Return: None
+func (lists *TrackerLists) addEasyList(content []byte, category TrackerCategory) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "||") {
+			continue
+		}
+
+		rule, _, _ := strings.Cut(line[2:], "$")
+		host, found := strings.CutSuffix(rule, "^")
+		if !found || strings.ContainsAny(host, "/*") {
+			continue
+		}
+		lists.add(host, category)
+	}
+}
+
+// Function: Add
+// Operation: Maps the domain to the category, unless a list has already mapped it.
+// Return: None
+func (lists *TrackerLists) add(domain string, category TrackerCategory) {
+	domain = normalizeCookieDomain(domain)
+	if domain == "" {
+		return
+	}
+	if _, ok := lists.domains[domain]; !ok {
+		lists.domains[domain] = category
+	}
+}
+
+// Function: Classify
+// Operation: Finds the category of the host or cookie domain, checking the host
+// and then each parent domain.
+// Return: TrackerCategory, empty if the host is not a known tracker
+func (lists *TrackerLists) Classify(host string) TrackerCategory {
+	if lists == nil {
+		return ""
+	}
+
+	host = normalizeCookieDomain(host)
+	for host != "" {
+		if category, ok := lists.domains[host]; ok {
+			return category
+		}
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			break
+		}
+		host = parent
+	}
+	return ""
+}
+
+// Function: Is Tracker
+// Operation: Reports whether the category tracks users. CDN hosts, listed only by
+// the local cdn list, are classified but are not trackers.
+// Return: bool
+func (category TrackerCategory) IsTracker() bool {
+	return category != "" && category != TrackerCDN
+}
+
+// Function: Tracker Label
+// Operation: Returns the category for reports.
+// Return: String
+func trackerLabel(category TrackerCategory) string {
+	if category == "" {
+		return "unlisted"
+	}
+	return string(category)
+}
+
+// Function: Version Of
+// Operation: Returns the version of the lists for reports.
+// Return: String
+func (lists *TrackerLists) versionOf() string {
+	if lists == nil {
+		return "none"
+	}
+	return lists.Version
+}
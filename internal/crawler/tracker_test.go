@@ -0,0 +1,77 @@
+package crawler
+
+import "testing"
+
+func TestTrackerClassify(t *testing.T) {
+	disconnect := `{"categories": {
+		"Advertising": [{"AdCo": {"https://adco.com/": ["adco.com", "AdCo-CDN.net"]}}],
+		"Analytics": [{"Stats": {"https://stats.io/": ["stats.io"], "dnt": "eff"}}],
+		"Content": [{"Videos": {"https://videos.tv/": ["videos.tv"]}}],
+		"Email": [{"Mail": {"https://mail.com/": ["mail.com"]}}]
+	}}`
+	easyList := `[Adblock Plus 2.0]
+! Title: inline test list
+||pixel.tracker.org^
+  ||beacon.example.net^$third-party,image
+||adco.com^
+||ads.example.com/banner^
+||wild*.example.com^
+||noanchor.example.com
+@@||allowed.tracker.org^
+example.org##.ad
+|https://full.example.org^
+`
+
+	lists := &TrackerLists{domains: make(map[string]TrackerCategory)}
+	if err := lists.addDisconnect([]byte(disconnect)); err != nil {
+		t.Fatalf("addDisconnect: %v", err)
+	}
+	lists.addEasyList([]byte(easyList), TrackerFingerprinting)
+	lists.addEasyList([]byte("||cdn.example.com^\n"), TrackerCDN)
+
+	tests := []struct {
+		host string
+		want TrackerCategory
+	}{
+		{"adco.com", TrackerAdvertising}, // Listed by EasyList too, the first list wins.
+		{"www.adco.com", TrackerAdvertising},
+		{".adco-cdn.net", TrackerAdvertising},
+		{"STATS.io", TrackerAnalytics},
+		{"player.videos.tv", TrackerContent},
+		{"pixel.tracker.org", TrackerFingerprinting},
+		{"a.b.pixel.tracker.org", TrackerFingerprinting},
+		{"beacon.example.net", TrackerFingerprinting},
+		{"cdn.example.com", TrackerCDN},
+
+		// Parents of listed hosts, lookalikes and skipped rules are not listed.
+		{"tracker.org", ""},
+		{"example.net", ""},
+		{"notadco.com", ""},
+		{"adco.com.evil.net", ""},
+		{"mail.com", ""},
+		{"ads.example.com", ""},
+		{"wild1.example.com", ""},
+		{"noanchor.example.com", ""},
+		{"allowed.tracker.org", ""},
+		{"example.org", ""},
+		{"full.example.org", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := lists.Classify(test.host); got != test.want {
+			t.Errorf("Classify(%q) = %q, want %q", test.host, got, test.want)
+		}
+	}
+
+	if lists.Classify("cdn.example.com").IsTracker() || !lists.Classify("stats.io").IsTracker() {
+		t.Errorf("CDN hosts must not count as trackers, listed hosts must")
+	}
+	var none *TrackerLists
+	if got := none.Classify("adco.com"); got != "" {
+		t.Errorf("nil lists classified adco.com as %q", got)
+	}
+	if err := lists.addDisconnect([]byte(`{"categories": [`)); err == nil {
+		t.Errorf("addDisconnect accepted broken JSON")
+	}
+}
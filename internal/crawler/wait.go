@@ -0,0 +1,171 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Wait Mode: Represents how long the crawler stays on a page before reading cookies.
+type WaitMode string
+
+const (
+	WaitFixed        WaitMode = "fixed"         // Wait CrawlOptions.Duration.
+	WaitNetworkIdle  WaitMode = "networkidle"   // Wait until the network is idle.
+	WaitDOMReady     WaitMode = "domready"      // Wait for DOMContentLoaded, then Grace.
+	WaitCookieStable WaitMode = "cookie-stable" // Poll cookies until unchanged for StableFor.
+)
+
+// Wait Strategy: Represents the wait mode and its timings, all in milliseconds.
+// MaxWait is the hard maximum for networkidle, domready and cookie-stable.
+type WaitStrategy struct {
+	Mode         WaitMode `json:"mode"`
+	Grace        int      `json:"grace"`
+	StableFor    int      `json:"stableFor"`
+	PollInterval int      `json:"pollInterval"`
+	MaxWait      int      `json:"maxWait"`
+}
+
+// Wait Result: Represents the wait that actually happened on a page.
+type WaitResult struct {
+	Mode   WaitMode
+	Waited time.Duration
+	// Settled is false when the wait hit MaxWait or was cancelled
+	// before its condition was met.
+	Settled bool
+}
+
+// ---- Global Definitions ---- //
+
+// Defaults used when a wait strategy leaves a timing at 0.
+const DEFAULT_WAIT_STABLE_FOR int = 3000   // 3s
+const DEFAULT_WAIT_POLL_INTERVAL int = 500 // 0.5s
+const DEFAULT_WAIT_MAX int = 30000         // 30s
+
+// ---- Functions ---- //
+
+// Function: Validate
+// Operation: Checks the wait mode and timings.
+// Return: Error
+func (strategy WaitStrategy) Validate() error {
+	switch strategy.Mode {
+	case "", WaitFixed, WaitNetworkIdle, WaitDOMReady, WaitCookieStable:
+	default:
+		return fmt.Errorf("wait mode %s is not supported", strategy.Mode)
+	}
+
+	if strategy.Grace < 0 || strategy.StableFor < 0 || strategy.PollInterval < 0 || strategy.MaxWait < 0 {
+		return fmt.Errorf("wait timings cannot be negative")
+	}
+
+	return nil
+}
+
+// Function: With Defaults
+// Operation: Fills in the mode and any timing left at 0.
+// Return: WaitStrategy
+func (strategy WaitStrategy) withDefaults() WaitStrategy {
+	if strategy.Mode == "" {
+		strategy.Mode = WaitFixed
+	}
+	if strategy.StableFor == 0 {
+		strategy.StableFor = DEFAULT_WAIT_STABLE_FOR
+	}
+	if strategy.PollInterval == 0 {
+		strategy.PollInterval = DEFAULT_WAIT_POLL_INTERVAL
+	}
+	if strategy.MaxWait == 0 {
+		strategy.MaxWait = DEFAULT_WAIT_MAX
+	}
+	return strategy
+}
+
+// Function: Wait For Page
+// Operation: Waits on the loaded page according to the strategy. duration is the
+// fixed wait in milliseconds used by WaitFixed.
+// Return: WaitResult
+func waitForPage(ctx context.Context, page playwright.Page, browserContext playwright.BrowserContext,
+	strategy WaitStrategy, duration int) WaitResult {
+
+	strategy = strategy.withDefaults()
+	start := time.Now()
+	result := WaitResult{Mode: strategy.Mode}
+
+	switch strategy.Mode {
+	case WaitNetworkIdle:
+		err := page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+			State:   playwright.LoadStateNetworkidle,
+			Timeout: playwright.Float(float64(strategy.MaxWait)),
+		})
+		result.Settled = err == nil && ctx.Err() == nil
+
+	case WaitDOMReady:
+		err := page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+			State:   playwright.LoadStateDomcontentloaded,
+			Timeout: playwright.Float(float64(strategy.MaxWait)),
+		})
+		result.Settled = err == nil && sleepContext(ctx, strategy.Grace) == nil
+
+	case WaitCookieStable:
+		result.Settled = waitCookieStable(ctx, browserContext, strategy)
+
+	default:
+		result.Settled = sleepContext(ctx, duration) == nil
+	}
+
+	result.Waited = time.Since(start)
+	return result
+}
+
+// Function: Wait Cookie Stable
+// Operation: Polls the context cookies until the set has not changed for
+// StableFor, or MaxWait has passed.
+// Return: True if the cookie set settled before MaxWait
+func waitCookieStable(ctx context.Context, browserContext playwright.BrowserContext, strategy WaitStrategy) bool {
+	start := time.Now()
+	lastChange := start
+	lastSignature := ""
+
+	for {
+		cookies, err := browserContext.Cookies()
+		if err != nil {
+			return false
+		}
+
+		signature := cookieSignature(cookies)
+		if signature != lastSignature {
+			lastSignature = signature
+			lastChange = time.Now()
+		}
+
+		if time.Since(lastChange) >= time.Duration(strategy.StableFor)*time.Millisecond {
+			return true
+		}
+		if time.Since(start) >= time.Duration(strategy.MaxWait)*time.Millisecond {
+			return false
+		}
+
+		if sleepContext(ctx, strategy.PollInterval) != nil {
+			return false
+		}
+	}
+}
+
+// Function: Cookie Signature
+// Operation: Builds a string that changes whenever a cookie is added, removed or changed.
+// Return: String
+func cookieSignature(cookies []playwright.Cookie) string {
+	entries := make([]string, 0, len(cookies))
+	for _, c := range cookies {
+		entries = append(entries, c.Name+"|"+c.Domain+"|"+c.Path+"="+c.Value)
+	}
+	sort.Strings(entries)
+
+	return strings.Join(entries, "\n")
+}
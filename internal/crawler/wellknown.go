@@ -0,0 +1,409 @@
+package crawler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Ads.txt Record: Represents one authorized seller line of ads.txt or app-ads.txt.
+type AdsTxtRecord struct {
+	Domain          string // Advertising system, lowercased.
+	AccountID       string
+	Relationship    string // DIRECT or RESELLER.
+	CertificationID string
+}
+
+// Ads.txt: Represents a parsed ads.txt or app-ads.txt file.
+// Variables holds CONTACT, SUBDOMAIN, OWNERDOMAIN, MANAGERDOMAIN and others by name.
+type AdsTxt struct {
+	URL       string
+	Status    int
+	Found     bool
+	Records   []AdsTxtRecord
+	Variables map[string][]string
+	Invalid   int // Lines that are neither records nor variables.
+	Error     string
+}
+
+// Sellers JSON: Represents the counts of a parsed sellers.json file. The seller
+// list itself is not kept, ad systems list hundreds of thousands.
+type SellersJSON struct {
+	URL          string
+	Status       int
+	Found        bool
+	Version      string
+	ContactEmail string
+	Sellers      int
+	Publishers   int
+	Intermediary int
+	Both         int
+	Confidential int
+	Error        string
+}
+
+// Security Txt: Represents the fields of a parsed security.txt file (RFC 9116).
+type SecurityTxt struct {
+	URL     string
+	Status  int
+	Found   bool
+	Fields  map[string][]string // Field names lowercased, e.g. "contact", "policy".
+	Expires time.Time
+	Expired bool
+	Signed  bool // OpenPGP cleartext signature.
+	Error   string
+}
+
+// Well-Known Files: Represents the privacy and advertising files a site publishes.
+type WellKnownFiles struct {
+	GPC       GPCSupport
+	AdsTxt    AdsTxt
+	AppAdsTxt AdsTxt
+	Sellers   SellersJSON
+	Security  SecurityTxt
+}
+
+// ---- Global Definitions ---- //
+
+// Paths of the files, relative to the site origin. security.txt is looked for
+// at the legacy root path when it is not under /.well-known.
+const (
+	ADS_TXT_PATH             string = "/ads.txt"
+	APP_ADS_TXT_PATH         string = "/app-ads.txt"
+	SELLERS_JSON_PATH        string = "/sellers.json"
+	SECURITY_TXT_PATH        string = "/.well-known/security.txt"
+	SECURITY_TXT_LEGACY_PATH string = "/security.txt"
+)
+
+// ---- Functions ---- //
+
+// Function: Fetch Well-Known Files
+// Operation: Fetches and parses gpc.json, ads.txt, app-ads.txt, sellers.json and
+// security.txt from the origin of the site.
+// Return: WellKnownFiles
+func FetchWellKnownFiles(ctx context.Context, client *HTTPClient, siteURL, userAgent string, verbose *bool) WellKnownFiles {
+	files := WellKnownFiles{GPC: FetchGPCSupport(ctx, client, siteURL, userAgent, verbose)}
+
+	origin, err := siteOrigin(siteURL)
+	if err != nil {
+		files.AdsTxt.Error = err.Error()
+		files.AppAdsTxt.Error = err.Error()
+		files.Sellers.Error = err.Error()
+		files.Security.Error = err.Error()
+		return files
+	}
+
+	files.AdsTxt = fetchAdsTxt(ctx, client, origin+ADS_TXT_PATH, userAgent, verbose)
+	files.AppAdsTxt = fetchAdsTxt(ctx, client, origin+APP_ADS_TXT_PATH, userAgent, verbose)
+	files.Sellers = fetchSellersJSON(ctx, client, origin+SELLERS_JSON_PATH, userAgent, verbose)
+
+	files.Security = fetchSecurityTxt(ctx, client, origin+SECURITY_TXT_PATH, userAgent, verbose)
+	if !files.Security.Found {
+		if legacy := fetchSecurityTxt(ctx, client, origin+SECURITY_TXT_LEGACY_PATH, userAgent, verbose); legacy.Found {
+			files.Security = legacy
+		}
+	}
+
+	return files
+}
+
+// Function: Site Origin
+// Operation: Returns the scheme and host of the site URL.
+// Return: String, Error
+func siteOrigin(siteURL string) (string, error) {
+	parsedURL, err := url.Parse(siteURL)
+	if err != nil || parsedURL.Host == "" {
+		return "", fmt.Errorf("invalid site URL %s", siteURL)
+	}
+	return parsedURL.Scheme + "://" + parsedURL.Host, nil
+}
+
+// Function: Fetch Well-Known
+// Operation: Fetches one file with the client. Anything but a 200 response is an
+// error, and so is an HTML page, which sites often serve instead of a 404, or a
+// file cut at the client's body limit.
+// Return: Body, Status Code, Error
+func fetchWellKnown(ctx context.Context, client *HTTPClient, fileURL, userAgent string, verbose *bool) ([]byte, int, error) {
+	headers, body, status, err := client.FetchPacket(ctx, fileURL, userAgent, verbose)
+	if err != nil {
+		// A file cut at the body limit would be parsed incomplete.
+		return nil, status, err
+	}
+	if status != http.StatusOK {
+		return nil, status, fmt.Errorf("status %d", status)
+	}
+	if strings.Contains(strings.ToLower(http.Header(headers).Get("Content-Type")), "text/html") {
+		return nil, status, fmt.Errorf("served an HTML page")
+	}
+	return body, status, nil
+}
+
+// Function: Fetch Ads.txt
+// Operation: Fetches and parses an ads.txt or app-ads.txt file.
+// Return: AdsTxt
+func fetchAdsTxt(ctx context.Context, client *HTTPClient, fileURL, userAgent string, verbose *bool) AdsTxt {
+	body, status, err := fetchWellKnown(ctx, client, fileURL, userAgent, verbose)
+	if err != nil {
+		return AdsTxt{URL: fileURL, Status: status, Error: err.Error()}
+	}
+
+	adsTxt := parseAdsTxt(body)
+	adsTxt.URL = fileURL
+	adsTxt.Status = status
+	adsTxt.Found = true
+	return adsTxt
+}
+
+// Function: Parse Ads.txt
+// Operation: Reads the records and variables of an ads.txt file (IAB ads.txt 1.1).
+// Comments start with "#", extension fields after ";" are ignored.
+// Return: AdsTxt
+func parseAdsTxt(content []byte) AdsTxt {
+	adsTxt := AdsTxt{Variables: make(map[string][]string)}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Variables are name=value with no comma before the "=".
+		if name, value, found := strings.Cut(line, "="); found && !strings.Contains(name, ",") {
+			name = strings.ToUpper(strings.TrimSpace(name))
+			adsTxt.Variables[name] = append(adsTxt.Variables[name], strings.TrimSpace(value))
+			continue
+		}
+
+		record, _, _ := strings.Cut(line, ";")
+		fields := strings.Split(record, ",")
+		if len(fields) < 3 {
+			adsTxt.Invalid++
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		relationship := strings.ToUpper(fields[2])
+		if fields[0] == "" || fields[1] == "" || (relationship != "DIRECT" && relationship != "RESELLER") {
+			adsTxt.Invalid++
+			continue
+		}
+
+		entry := AdsTxtRecord{
+			Domain:       strings.ToLower(fields[0]),
+			AccountID:    fields[1],
+			Relationship: relationship,
+		}
+		if len(fields) > 3 {
+			entry.CertificationID = fields[3]
+		}
+		adsTxt.Records = append(adsTxt.Records, entry)
+	}
+
+	return adsTxt
+}
+
+// Function: Count
+// Operation: Counts the direct and reseller records and the distinct ad systems.
+// Return: Direct (int), Reseller (int), Ad Systems (int)
+func (adsTxt AdsTxt) Count() (int, int, int) {
+	direct, reseller := 0, 0
+	systems := make(map[string]bool)
+	for _, record := range adsTxt.Records {
+		if record.Relationship == "DIRECT" {
+			direct++
+		} else {
+			reseller++
+		}
+		systems[record.Domain] = true
+	}
+	return direct, reseller, len(systems)
+}
+
+// Function: Fetch Sellers JSON
+// Operation: Fetches sellers.json and counts its sellers by type.
+// Return: SellersJSON
+func fetchSellersJSON(ctx context.Context, client *HTTPClient, fileURL, userAgent string, verbose *bool) SellersJSON {
+	sellers := SellersJSON{URL: fileURL}
+
+	body, status, err := fetchWellKnown(ctx, client, fileURL, userAgent, verbose)
+	sellers.Status = status
+	if err != nil {
+		sellers.Error = err.Error()
+		return sellers
+	}
+
+	var file struct {
+		Version      any    `json:"version"`
+		ContactEmail string `json:"contact_email"`
+		Sellers      []struct {
+			SellerType     string `json:"seller_type"`
+			IsConfidential any    `json:"is_confidential"`
+		} `json:"sellers"`
+	}
+	err = json.Unmarshal(body, &file)
+	if err != nil {
+		sellers.Error = fmt.Sprintf("error parsing sellers.json: %v", err)
+		return sellers
+	}
+
+	sellers.Found = true
+	if file.Version != nil {
+		sellers.Version = fmt.Sprint(file.Version)
+	}
+	sellers.ContactEmail = file.ContactEmail
+	sellers.Sellers = len(file.Sellers)
+	for _, seller := range file.Sellers {
+		switch strings.ToUpper(seller.SellerType) {
+		case "PUBLISHER":
+			sellers.Publishers++
+		case "INTERMEDIARY":
+			sellers.Intermediary++
+		case "BOTH":
+			sellers.Both++
+		}
+		// Written as 0/1 by the spec, and as a boolean by some ad systems.
+		if seller.IsConfidential == true || seller.IsConfidential == float64(1) {
+			sellers.Confidential++
+		}
+	}
+
+	return sellers
+}
+
+// Function: Fetch Security Txt
+// Operation: Fetches and parses a security.txt file.
+// Return: SecurityTxt
+func fetchSecurityTxt(ctx context.Context, client *HTTPClient, fileURL, userAgent string, verbose *bool) SecurityTxt {
+	body, status, err := fetchWellKnown(ctx, client, fileURL, userAgent, verbose)
+	if err != nil {
+		return SecurityTxt{URL: fileURL, Status: status, Error: err.Error()}
+	}
+
+	security := parseSecurityTxt(body, time.Now())
+	security.URL = fileURL
+	security.Status = status
+	security.Found = true
+	return security
+}
+
+// Function: Parse Security Txt
+// Operation: Reads the "Field: value" lines of a security.txt file and checks
+// its Expires field against now. Signature blocks are skipped.
+// Return: SecurityTxt
+func parseSecurityTxt(content []byte, now time.Time) SecurityTxt {
+	security := SecurityTxt{Fields: make(map[string][]string)}
+
+	inSignature := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "-----BEGIN PGP SIGNED MESSAGE-----"):
+			security.Signed = true
+			continue
+		case strings.HasPrefix(line, "-----BEGIN PGP SIGNATURE-----"):
+			inSignature = true
+			continue
+		case strings.HasPrefix(line, "-----END PGP SIGNATURE-----"):
+			inSignature = false
+			continue
+		}
+		if inSignature || line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		// The cleartext signature header ("Hash: SHA256") is not a field.
+		if security.Signed && name == "hash" {
+			continue
+		}
+		security.Fields[name] = append(security.Fields[name], strings.TrimSpace(value))
+	}
+
+	if expires, ok := security.Fields["expires"]; ok {
+		if parsed, err := time.Parse(time.RFC3339, expires[0]); err == nil {
+			security.Expires = parsed
+			security.Expired = parsed.Before(now)
+		}
+	}
+
+	return security
+}
+
+// Function: Write Well-Known Files
+// Operation: Writes one line per file, with the details found in it, for reports.
+// Return: None
+func writeWellKnownFiles(report *strings.Builder, files WellKnownFiles) {
+	writeGPCSupport(report, files.GPC)
+
+	for _, file := range []struct {
+		name   string
+		adsTxt AdsTxt
+	}{{"ads.txt", files.AdsTxt}, {"app-ads.txt", files.AppAdsTxt}} {
+		if !file.adsTxt.Found {
+			writeNotFound(report, file.name, file.adsTxt.Error)
+			continue
+		}
+		direct, reseller, systems := file.adsTxt.Count()
+		report.WriteString(fmt.Sprintf("%s: %d records (%d direct, %d reseller), %d ad systems, %d invalid lines\n",
+			file.name, len(file.adsTxt.Records), direct, reseller, systems, file.adsTxt.Invalid))
+		for _, name := range []string{"OWNERDOMAIN", "MANAGERDOMAIN"} {
+			if values, ok := file.adsTxt.Variables[name]; ok {
+				report.WriteString(fmt.Sprintf("\t%s: %s\n", name, strings.Join(values, ", ")))
+			}
+		}
+	}
+
+	sellers := files.Sellers
+	if sellers.Found {
+		report.WriteString(fmt.Sprintf("sellers.json: %d sellers (%d publisher, %d intermediary, %d both, %d confidential)\n",
+			sellers.Sellers, sellers.Publishers, sellers.Intermediary, sellers.Both, sellers.Confidential))
+	} else {
+		writeNotFound(report, "sellers.json", sellers.Error)
+	}
+
+	security := files.Security
+	if security.Found {
+		report.WriteString(fmt.Sprintf("security.txt: contact %s, signed %t", strings.Join(security.Fields["contact"], ", "), security.Signed))
+		if !security.Expires.IsZero() {
+			report.WriteString(fmt.Sprintf(", expires %s", security.Expires.Format("2006-01-02")))
+		} else {
+			report.WriteString(", no valid Expires")
+		}
+		if security.Expired {
+			report.WriteString(" [expired]")
+		}
+		report.WriteString("\n")
+	} else {
+		writeNotFound(report, "security.txt", security.Error)
+	}
+}
+
+// Function: Write Not Found
+// Operation: Writes the line of a file that was not found, or not fetched when
+// there is no error.
+// Return: None
+func writeNotFound(report *strings.Builder, name, err string) {
+	if err == "" {
+		report.WriteString(fmt.Sprintf("%s: not fetched\n", name))
+		return
+	}
+	report.WriteString(fmt.Sprintf("%s: not found (%s)\n", name, err))
+}
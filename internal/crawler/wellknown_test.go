@@ -0,0 +1,188 @@
+package crawler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseAdsTxt(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		records   []AdsTxtRecord
+		variables map[string][]string
+		invalid   int
+		counts    [3]int // Direct, reseller, ad systems.
+	}{
+		{
+			name:      "empty",
+			content:   "",
+			variables: map[string][]string{},
+		},
+		{
+			name: "records with and without certification",
+			content: "google.com, pub-1234567890, DIRECT, f08c47fec0942fa0\n" +
+				"AppNexus.com,1908,reseller\n" +
+				"google.com, pub-0987654321, RESELLER, f08c47fec0942fa0\n",
+			records: []AdsTxtRecord{
+				{Domain: "google.com", AccountID: "pub-1234567890", Relationship: "DIRECT", CertificationID: "f08c47fec0942fa0"},
+				{Domain: "appnexus.com", AccountID: "1908", Relationship: "RESELLER"},
+				{Domain: "google.com", AccountID: "pub-0987654321", Relationship: "RESELLER", CertificationID: "f08c47fec0942fa0"},
+			},
+			variables: map[string][]string{},
+			counts:    [3]int{1, 2, 2},
+		},
+		{
+			name: "comments, extensions and variables",
+			content: "# ads.txt for example.com\n" +
+				"\n" +
+				"  rubiconproject.com, 12345, DIRECT # inline comment\n" +
+				"openx.com, 540000, DIRECT, 6a698e2ec38604c6; extension=value\n" +
+				"contact=adops@example.com\n" +
+				"OWNERDOMAIN = example.com\n" +
+				"managerdomain=manager.com\n" +
+				"subdomain=news.example.com\n" +
+				"subdomain=shop.example.com\n",
+			records: []AdsTxtRecord{
+				{Domain: "rubiconproject.com", AccountID: "12345", Relationship: "DIRECT"},
+				{Domain: "openx.com", AccountID: "540000", Relationship: "DIRECT", CertificationID: "6a698e2ec38604c6"},
+			},
+			variables: map[string][]string{
+				"CONTACT":       {"adops@example.com"},
+				"OWNERDOMAIN":   {"example.com"},
+				"MANAGERDOMAIN": {"manager.com"},
+				"SUBDOMAIN":     {"news.example.com", "shop.example.com"},
+			},
+			counts: [3]int{2, 0, 2},
+		},
+		{
+			name: "invalid lines",
+			content: "google.com, pub-1\n" +
+				"google.com, pub-1, PARTNER\n" +
+				", pub-1, DIRECT\n" +
+				"google.com, , DIRECT\n" +
+				"<html><body>Not found</body></html>\n" +
+				"indexexchange.com, 1, DIRECT\n",
+			records: []AdsTxtRecord{
+				{Domain: "indexexchange.com", AccountID: "1", Relationship: "DIRECT"},
+			},
+			variables: map[string][]string{},
+			invalid:   5,
+			counts:    [3]int{1, 0, 1},
+		},
+		{
+			name:    "value with an equals sign is a record when a comma comes first",
+			content: "example-ssp.com, id=42, DIRECT\n",
+			records: []AdsTxtRecord{
+				{Domain: "example-ssp.com", AccountID: "id=42", Relationship: "DIRECT"},
+			},
+			variables: map[string][]string{},
+			counts:    [3]int{1, 0, 1},
+		},
+	}
+
+	for _, test := range tests {
+		adsTxt := parseAdsTxt([]byte(test.content))
+		if !reflect.DeepEqual(adsTxt.Records, test.records) {
+			t.Errorf("%s: records %+v, want %+v", test.name, adsTxt.Records, test.records)
+		}
+		if !reflect.DeepEqual(adsTxt.Variables, test.variables) {
+			t.Errorf("%s: variables %v, want %v", test.name, adsTxt.Variables, test.variables)
+		}
+		if adsTxt.Invalid != test.invalid {
+			t.Errorf("%s: %d invalid lines, want %d", test.name, adsTxt.Invalid, test.invalid)
+		}
+		if direct, reseller, systems := adsTxt.Count(); [3]int{direct, reseller, systems} != test.counts {
+			t.Errorf("%s: counts %d %d %d, want %v", test.name, direct, reseller, systems, test.counts)
+		}
+	}
+}
+
+func TestParseSecurityTxt(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		content string
+		fields  map[string][]string
+		expires time.Time
+		expired bool
+		signed  bool
+	}{
+		{
+			name: "plain",
+			content: "# Our security policy\n" +
+				"Contact: mailto:security@example.com\n" +
+				"Contact: https://example.com/security\n" +
+				"Expires: 2027-01-01T00:00:00Z\n" +
+				"Preferred-Languages: en, de\n" +
+				"Policy: https://example.com/policy\n",
+			fields: map[string][]string{
+				"contact":             {"mailto:security@example.com", "https://example.com/security"},
+				"expires":             {"2027-01-01T00:00:00Z"},
+				"preferred-languages": {"en, de"},
+				"policy":              {"https://example.com/policy"},
+			},
+			expires: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "expired",
+			content: "CONTACT: mailto:security@example.com\n" +
+				"expires: 2025-12-31T23:59:59+01:00\n",
+			fields: map[string][]string{
+				"contact": {"mailto:security@example.com"},
+				"expires": {"2025-12-31T23:59:59+01:00"},
+			},
+			expires: time.Date(2025, 12, 31, 23, 59, 59, 0, time.FixedZone("", 3600)),
+			expired: true,
+		},
+		{
+			name: "unparsable expiry and lines without fields",
+			content: "Contact: mailto:security@example.com\n" +
+				"Expires: next year\n" +
+				"this line has no field\n",
+			fields: map[string][]string{
+				"contact": {"mailto:security@example.com"},
+				"expires": {"next year"},
+			},
+		},
+		{
+			name: "signed",
+			content: "-----BEGIN PGP SIGNED MESSAGE-----\n" +
+				"Hash: SHA256\n" +
+				"\n" +
+				"Contact: mailto:security@example.com\n" +
+				"Expires: 2027-01-01T00:00:00Z\n" +
+				"-----BEGIN PGP SIGNATURE-----\n" +
+				"\n" +
+				"iQIzBAEBCAAdFiEE: not a field\n" +
+				"-----END PGP SIGNATURE-----\n",
+			fields: map[string][]string{
+				"contact": {"mailto:security@example.com"},
+				"expires": {"2027-01-01T00:00:00Z"},
+			},
+			expires: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			signed:  true,
+		},
+		{
+			name:    "hash is a field when unsigned",
+			content: "Hash: SHA256\n",
+			fields:  map[string][]string{"hash": {"SHA256"}},
+		},
+	}
+
+	for _, test := range tests {
+		security := parseSecurityTxt([]byte(test.content), now)
+		if !reflect.DeepEqual(security.Fields, test.fields) {
+			t.Errorf("%s: fields %v, want %v", test.name, security.Fields, test.fields)
+		}
+		if !security.Expires.Equal(test.expires) || security.Expired != test.expired {
+			t.Errorf("%s: expires %v (expired %t), want %v (%t)", test.name, security.Expires, security.Expired,
+				test.expires, test.expired)
+		}
+		if security.Signed != test.signed {
+			t.Errorf("%s: signed %t, want %t", test.name, security.Signed, test.signed)
+		}
+	}
+}
@@ -2,13 +2,14 @@ package jmppoint
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"privcrawler/internal/crawler"
 	"regexp"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 )
 
@@ -20,6 +21,7 @@ var PORT int = 22
 type BrowserStats struct {
 	Browser                string
 	TotalReports           int
+	FailedReports          int
 	TotalCookies           int
 	TotalFirstParty        int
 	TotalThirdParty        int
@@ -43,6 +45,33 @@ type ProcessOptions struct {
 	hidden   bool
 	duration int
 	verbose  bool
+	// Same-site link depth and page budget.
+	depth    int
+	maxPages int
+	// Deadline for the whole crawl in milliseconds, 0 means none.
+	timeout int
+	// Retry policy for navigation failures, nil uses the crawler default.
+	retry *crawler.RetryPolicy
+	// How long to stay on each page, the zero value waits duration.
+	wait crawler.WaitStrategy
+	// Milliseconds between cookie timeline snapshots, 0 once per page.
+	snapshotInterval int
+	// What to do with a consent banner on the landing page.
+	consent crawler.ConsentMode
+	// Tracker lists loaded at startup, nil reads them for every crawl.
+	trackers *crawler.TrackerLists
+	// Cookie catalog loaded at startup, nil reads it for every crawl.
+	catalog *crawler.CookieCatalog
+	// Crawl without and with GPC/DNT privacy signals.
+	gpcCompare bool
+	// HSTS preload list loaded at startup, nil reads it for every crawl.
+	hstsPreload *crawler.HSTSPreloadList
+	// Client for requests made outside the browser, nil reads its settings for every crawl.
+	httpClient *crawler.HTTPClient
+	// DNS resolver for CNAME cloaking, nil skips the lookups.
+	cname *crawler.CNAMEResolver
+	// Shared browsers, nil starts a browser for this process only.
+	pool *crawler.BrowserPool
 }
 
 // Process: Holds the option for the given process.
@@ -110,6 +139,104 @@ func WithDuration(duration int) ProcessOptionsFunc {
 	}
 }
 
+// WithDepth sets the depth of same-site links to follow
+func WithDepth(depth int) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.depth = depth
+	}
+}
+
+// WithMaxPages sets the maximum pages visited when following links
+func WithMaxPages(maxPages int) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.maxPages = maxPages
+	}
+}
+
+// WithTimeout sets the deadline for the whole crawl in milliseconds
+func WithTimeout(timeout int) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// WithRetryPolicy sets the retry policy for navigation failures
+func WithRetryPolicy(retry *crawler.RetryPolicy) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.retry = retry
+	}
+}
+
+// WithWaitStrategy sets how long to stay on each page
+func WithWaitStrategy(wait crawler.WaitStrategy) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.wait = wait
+	}
+}
+
+// WithSnapshotInterval sets the milliseconds between cookie timeline snapshots
+func WithSnapshotInterval(snapshotInterval int) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.snapshotInterval = snapshotInterval
+	}
+}
+
+// WithConsent sets what to do with a consent banner
+func WithConsent(consent crawler.ConsentMode) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.consent = consent
+	}
+}
+
+// WithTrackers sets the tracker lists used to classify hosts
+func WithTrackers(trackers *crawler.TrackerLists) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.trackers = trackers
+	}
+}
+
+// WithCatalog sets the cookie catalog used to label cookies
+func WithCatalog(catalog *crawler.CookieCatalog) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.catalog = catalog
+	}
+}
+
+// WithGPCCompare sets whether to compare crawls without and with privacy signals
+func WithGPCCompare(compare bool) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.gpcCompare = compare
+	}
+}
+
+// WithCNAMEResolver sets the resolver used to find cloaked trackers
+func WithCNAMEResolver(resolver *crawler.CNAMEResolver) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.cname = resolver
+	}
+}
+
+// WithHSTSPreload sets the HSTS preload list used to inspect TLS
+func WithHSTSPreload(preload *crawler.HSTSPreloadList) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.hstsPreload = preload
+	}
+}
+
+// WithHTTPClient sets the client for requests made outside the browser
+func WithHTTPClient(client *crawler.HTTPClient) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.httpClient = client
+	}
+}
+
+// WithPool sets the shared browser pool for the process
+func WithPool(pool *crawler.BrowserPool) ProcessOptionsFunc {
+	return func(opts *ProcessOptions) {
+		opts.pool = pool
+	}
+}
+
 // ---- CONSTRUCTOR ---- //
 func NewProcess(opts ...ProcessOptionsFunc) *Process {
 	o := defaultProcessOptions()
@@ -146,1365 +273,120 @@ func (p *Process) IsVerbose() bool {
 	return p.options.verbose
 }
 
+// GetDuration returns the duration option
+func (p *Process) GetDuration() int {
+	return p.options.duration
+}
+
 // GetPort returns the port
 func (p *Process) GetPort() int {
 	return p.port
 }
 
-// Run executes the privacy crawl with the given options
-func (p *Process) Run() error {
-	return crawler.RunPrivacyCrawl(
-		p.options.browser,
-		p.options.hidden,
-		p.options.url,
-		p.options.duration,
-		p.options.verbose,
-	)
+// Run executes the privacy crawl with the given options, stopping when ctx
+// is cancelled or the process timeout passes
+func (p *Process) Run(ctx context.Context) error {
+	if p.options.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(p.options.timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	_, err := crawler.RunPrivacyCrawlWithOptions(ctx, crawler.CrawlOptions{
+		Browser:  p.options.browser,
+		Hidden:   p.options.hidden,
+		URL:      p.options.url,
+		Duration: p.options.duration,
+		Verbose:  p.options.verbose,
+		Depth:    p.options.depth,
+		MaxPages: p.options.maxPages,
+		Pool:     p.options.pool,
+		Retry:    p.options.retry,
+		Wait:     p.options.wait,
+		Consent:  p.options.consent,
+		Trackers: p.options.trackers,
+		Catalog:  p.options.catalog,
+		CNAME:    p.options.cname,
+
+		HSTSPreload: p.options.hstsPreload,
+		HTTPClient:  p.options.httpClient,
+
+		SnapshotInterval: p.options.snapshotInterval,
+		GPCCompare:       p.options.gpcCompare,
+	})
+	return err
 }
 
 func RunServer() error {
 	fmt.Println("Starting privacy crawler with website-by-website processing...")
 
-	// -- WEBSITE: AMAZON -- //
-	fmt.Println("Processing AMAZON...")
-	var amazonWg sync.WaitGroup
-	amazonWg.Add(24) // 4 browsers × 6 durations = 24 processes
-
-	// All Amazon processes
-	go func() {
-		defer amazonWg.Done()
-		chrome_amazon_0 := NewProcess(WithBrowser(chrome), WithURL("https://www.amazon.com"), WithDuration(0))
-		chrome_amazon_0.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		webkit_amazon_0 := NewProcess(WithBrowser(webkit), WithURL("https://www.amazon.com"), WithDuration(0)) // Changed from safari
-		webkit_amazon_0.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		firefox_amazon_0 := NewProcess(WithBrowser(firefox), WithURL("https://www.amazon.com"), WithDuration(0))
-		firefox_amazon_0.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		chromium_amazon_0 := NewProcess(WithBrowser(chromium), WithURL("https://www.amazon.com"), WithDuration(0))
-		chromium_amazon_0.Run()
-	}()
-
-	go func() {
-		defer amazonWg.Done()
-		chrome_amazon_5 := NewProcess(WithBrowser(chrome), WithURL("https://www.amazon.com"), WithDuration(5000))
-		chrome_amazon_5.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		webkit_amazon_5 := NewProcess(WithBrowser(webkit), WithURL("https://www.amazon.com"), WithDuration(5000))
-		webkit_amazon_5.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		firefox_amazon_5 := NewProcess(WithBrowser(firefox), WithURL("https://www.amazon.com"), WithDuration(5000))
-		firefox_amazon_5.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		chromium_amazon_5 := NewProcess(WithBrowser(chromium), WithURL("https://www.amazon.com"), WithDuration(5000))
-		chromium_amazon_5.Run()
-	}()
-
-	go func() {
-		defer amazonWg.Done()
-		chrome_amazon_10 := NewProcess(WithBrowser(chrome), WithURL("https://www.amazon.com"), WithDuration(10000))
-		chrome_amazon_10.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		webkit_amazon_10 := NewProcess(WithBrowser(webkit), WithURL("https://www.amazon.com"), WithDuration(10000))
-		webkit_amazon_10.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		firefox_amazon_10 := NewProcess(WithBrowser(firefox), WithURL("https://www.amazon.com"), WithDuration(10000))
-		firefox_amazon_10.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		chromium_amazon_10 := NewProcess(WithBrowser(chromium), WithURL("https://www.amazon.com"), WithDuration(10000))
-		chromium_amazon_10.Run()
-	}()
-
-	go func() {
-		defer amazonWg.Done()
-		chrome_amazon_15 := NewProcess(WithBrowser(chrome), WithURL("https://www.amazon.com"), WithDuration(15000))
-		chrome_amazon_15.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		webkit_amazon_15 := NewProcess(WithBrowser(webkit), WithURL("https://www.amazon.com"), WithDuration(15000))
-		webkit_amazon_15.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		firefox_amazon_15 := NewProcess(WithBrowser(firefox), WithURL("https://www.amazon.com"), WithDuration(15000))
-		firefox_amazon_15.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		chromium_amazon_15 := NewProcess(WithBrowser(chromium), WithURL("https://www.amazon.com"), WithDuration(15000))
-		chromium_amazon_15.Run()
-	}()
-
-	go func() {
-		defer amazonWg.Done()
-		chrome_amazon_20 := NewProcess(WithBrowser(chrome), WithURL("https://www.amazon.com"), WithDuration(20000))
-		chrome_amazon_20.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		webkit_amazon_20 := NewProcess(WithBrowser(webkit), WithURL("https://www.amazon.com"), WithDuration(20000))
-		webkit_amazon_20.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		firefox_amazon_20 := NewProcess(WithBrowser(firefox), WithURL("https://www.amazon.com"), WithDuration(20000))
-		firefox_amazon_20.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		chromium_amazon_20 := NewProcess(WithBrowser(chromium), WithURL("https://www.amazon.com"), WithDuration(20000))
-		chromium_amazon_20.Run()
-	}()
-
-	go func() {
-		defer amazonWg.Done()
-		chrome_amazon_25 := NewProcess(WithBrowser(chrome), WithURL("https://www.amazon.com"), WithDuration(25000))
-		chrome_amazon_25.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		webkit_amazon_25 := NewProcess(WithBrowser(webkit), WithURL("https://www.amazon.com"), WithDuration(25000))
-		webkit_amazon_25.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		firefox_amazon_25 := NewProcess(WithBrowser(firefox), WithURL("https://www.amazon.com"), WithDuration(25000))
-		firefox_amazon_25.Run()
-	}()
-	go func() {
-		defer amazonWg.Done()
-		chromium_amazon_25 := NewProcess(WithBrowser(chromium), WithURL("https://www.amazon.com"), WithDuration(25000))
-		chromium_amazon_25.Run()
-	}()
-
-	amazonWg.Wait()
-	fmt.Println("AMAZON completed!")
-
-	// -- WEBSITE: YAHOO -- //
-	fmt.Println("Processing YAHOO...")
-	var yahooWg sync.WaitGroup
-	yahooWg.Add(24)
-
-	go func() {
-		defer yahooWg.Done()
-		chrome_yahoo_0 := NewProcess(WithBrowser(chrome), WithURL("https://www.yahoo.com"), WithDuration(0))
-		chrome_yahoo_0.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		webkit_yahoo_0 := NewProcess(WithBrowser(webkit), WithURL("https://www.yahoo.com"), WithDuration(0))
-		webkit_yahoo_0.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		firefox_yahoo_0 := NewProcess(WithBrowser(firefox), WithURL("https://www.yahoo.com"), WithDuration(0))
-		firefox_yahoo_0.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		chromium_yahoo_0 := NewProcess(WithBrowser(chromium), WithURL("https://www.yahoo.com"), WithDuration(0))
-		chromium_yahoo_0.Run()
-	}()
-
-	go func() {
-		defer yahooWg.Done()
-		chrome_yahoo_5 := NewProcess(WithBrowser(chrome), WithURL("https://www.yahoo.com"), WithDuration(5000))
-		chrome_yahoo_5.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		webkit_yahoo_5 := NewProcess(WithBrowser(webkit), WithURL("https://www.yahoo.com"), WithDuration(5000))
-		webkit_yahoo_5.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		firefox_yahoo_5 := NewProcess(WithBrowser(firefox), WithURL("https://www.yahoo.com"), WithDuration(5000))
-		firefox_yahoo_5.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		chromium_yahoo_5 := NewProcess(WithBrowser(chromium), WithURL("https://www.yahoo.com"), WithDuration(5000))
-		chromium_yahoo_5.Run()
-	}()
-
-	go func() {
-		defer yahooWg.Done()
-		chrome_yahoo_10 := NewProcess(WithBrowser(chrome), WithURL("https://www.yahoo.com"), WithDuration(10000))
-		chrome_yahoo_10.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		webkit_yahoo_10 := NewProcess(WithBrowser(webkit), WithURL("https://www.yahoo.com"), WithDuration(10000))
-		webkit_yahoo_10.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		firefox_yahoo_10 := NewProcess(WithBrowser(firefox), WithURL("https://www.yahoo.com"), WithDuration(10000))
-		firefox_yahoo_10.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		chromium_yahoo_10 := NewProcess(WithBrowser(chromium), WithURL("https://www.yahoo.com"), WithDuration(10000))
-		chromium_yahoo_10.Run()
-	}()
-
-	go func() {
-		defer yahooWg.Done()
-		chrome_yahoo_15 := NewProcess(WithBrowser(chrome), WithURL("https://www.yahoo.com"), WithDuration(15000))
-		chrome_yahoo_15.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		webkit_yahoo_15 := NewProcess(WithBrowser(webkit), WithURL("https://www.yahoo.com"), WithDuration(15000))
-		webkit_yahoo_15.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		firefox_yahoo_15 := NewProcess(WithBrowser(firefox), WithURL("https://www.yahoo.com"), WithDuration(15000))
-		firefox_yahoo_15.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		chromium_yahoo_15 := NewProcess(WithBrowser(chromium), WithURL("https://www.yahoo.com"), WithDuration(15000))
-		chromium_yahoo_15.Run()
-	}()
-
-	go func() {
-		defer yahooWg.Done()
-		chrome_yahoo_20 := NewProcess(WithBrowser(chrome), WithURL("https://www.yahoo.com"), WithDuration(20000))
-		chrome_yahoo_20.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		webkit_yahoo_20 := NewProcess(WithBrowser(webkit), WithURL("https://www.yahoo.com"), WithDuration(20000))
-		webkit_yahoo_20.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		firefox_yahoo_20 := NewProcess(WithBrowser(firefox), WithURL("https://www.yahoo.com"), WithDuration(20000))
-		firefox_yahoo_20.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		chromium_yahoo_20 := NewProcess(WithBrowser(chromium), WithURL("https://www.yahoo.com"), WithDuration(20000))
-		chromium_yahoo_20.Run()
-	}()
-
-	go func() {
-		defer yahooWg.Done()
-		chrome_yahoo_25 := NewProcess(WithBrowser(chrome), WithURL("https://www.yahoo.com"), WithDuration(25000))
-		chrome_yahoo_25.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		webkit_yahoo_25 := NewProcess(WithBrowser(webkit), WithURL("https://www.yahoo.com"), WithDuration(25000))
-		webkit_yahoo_25.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		firefox_yahoo_25 := NewProcess(WithBrowser(firefox), WithURL("https://www.yahoo.com"), WithDuration(25000))
-		firefox_yahoo_25.Run()
-	}()
-	go func() {
-		defer yahooWg.Done()
-		chromium_yahoo_25 := NewProcess(WithBrowser(chromium), WithURL("https://www.yahoo.com"), WithDuration(25000))
-		chromium_yahoo_25.Run()
-	}()
-
-	yahooWg.Wait()
-	fmt.Println("YAHOO completed!")
-
-	// -- WEBSITE: REDDIT -- //
-	fmt.Println("Processing REDDIT...")
-	var redditWg sync.WaitGroup
-	redditWg.Add(24)
-
-	go func() {
-		defer redditWg.Done()
-		chrome_reddit_0 := NewProcess(WithBrowser(chrome), WithURL("https://www.reddit.com"), WithDuration(0))
-		chrome_reddit_0.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		webkit_reddit_0 := NewProcess(WithBrowser(webkit), WithURL("https://www.reddit.com"), WithDuration(0))
-		webkit_reddit_0.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		firefox_reddit_0 := NewProcess(WithBrowser(firefox), WithURL("https://www.reddit.com"), WithDuration(0))
-		firefox_reddit_0.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		chromium_reddit_0 := NewProcess(WithBrowser(chromium), WithURL("https://www.reddit.com"), WithDuration(0))
-		chromium_reddit_0.Run()
-	}()
-
-	go func() {
-		defer redditWg.Done()
-		chrome_reddit_5 := NewProcess(WithBrowser(chrome), WithURL("https://www.reddit.com"), WithDuration(5000))
-		chrome_reddit_5.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		webkit_reddit_5 := NewProcess(WithBrowser(webkit), WithURL("https://www.reddit.com"), WithDuration(5000))
-		webkit_reddit_5.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		firefox_reddit_5 := NewProcess(WithBrowser(firefox), WithURL("https://www.reddit.com"), WithDuration(5000))
-		firefox_reddit_5.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		chromium_reddit_5 := NewProcess(WithBrowser(chromium), WithURL("https://www.reddit.com"), WithDuration(5000))
-		chromium_reddit_5.Run()
-	}()
-
-	go func() {
-		defer redditWg.Done()
-		chrome_reddit_10 := NewProcess(WithBrowser(chrome), WithURL("https://www.reddit.com"), WithDuration(10000))
-		chrome_reddit_10.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		webkit_reddit_10 := NewProcess(WithBrowser(webkit), WithURL("https://www.reddit.com"), WithDuration(10000))
-		webkit_reddit_10.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		firefox_reddit_10 := NewProcess(WithBrowser(firefox), WithURL("https://www.reddit.com"), WithDuration(10000))
-		firefox_reddit_10.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		chromium_reddit_10 := NewProcess(WithBrowser(chromium), WithURL("https://www.reddit.com"), WithDuration(10000))
-		chromium_reddit_10.Run()
-	}()
-
-	go func() {
-		defer redditWg.Done()
-		chrome_reddit_15 := NewProcess(WithBrowser(chrome), WithURL("https://www.reddit.com"), WithDuration(15000))
-		chrome_reddit_15.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		webkit_reddit_15 := NewProcess(WithBrowser(webkit), WithURL("https://www.reddit.com"), WithDuration(15000))
-		webkit_reddit_15.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		firefox_reddit_15 := NewProcess(WithBrowser(firefox), WithURL("https://www.reddit.com"), WithDuration(15000))
-		firefox_reddit_15.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		chromium_reddit_15 := NewProcess(WithBrowser(chromium), WithURL("https://www.reddit.com"), WithDuration(15000))
-		chromium_reddit_15.Run()
-	}()
-
-	go func() {
-		defer redditWg.Done()
-		chrome_reddit_20 := NewProcess(WithBrowser(chrome), WithURL("https://www.reddit.com"), WithDuration(20000))
-		chrome_reddit_20.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		webkit_reddit_20 := NewProcess(WithBrowser(webkit), WithURL("https://www.reddit.com"), WithDuration(20000))
-		webkit_reddit_20.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		firefox_reddit_20 := NewProcess(WithBrowser(firefox), WithURL("https://www.reddit.com"), WithDuration(20000))
-		firefox_reddit_20.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		chromium_reddit_20 := NewProcess(WithBrowser(chromium), WithURL("https://www.reddit.com"), WithDuration(20000))
-		chromium_reddit_20.Run()
-	}()
-
-	go func() {
-		defer redditWg.Done()
-		chrome_reddit_25 := NewProcess(WithBrowser(chrome), WithURL("https://www.reddit.com"), WithDuration(25000))
-		chrome_reddit_25.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		webkit_reddit_25 := NewProcess(WithBrowser(webkit), WithURL("https://www.reddit.com"), WithDuration(25000))
-		webkit_reddit_25.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		firefox_reddit_25 := NewProcess(WithBrowser(firefox), WithURL("https://www.reddit.com"), WithDuration(25000))
-		firefox_reddit_25.Run()
-	}()
-	go func() {
-		defer redditWg.Done()
-		chromium_reddit_25 := NewProcess(WithBrowser(chromium), WithURL("https://www.reddit.com"), WithDuration(25000))
-		chromium_reddit_25.Run()
-	}()
-
-	redditWg.Wait()
-	fmt.Println("REDDIT completed!")
-
-	// -- WEBSITE: PINTEREST -- //
-	fmt.Println("Processing PINTEREST...")
-	var pinterestWg sync.WaitGroup
-	pinterestWg.Add(24)
-
-	go func() {
-		defer pinterestWg.Done()
-		chrome_pinterest_0 := NewProcess(WithBrowser(chrome), WithURL("https://www.pinterest.com"), WithDuration(0))
-		chrome_pinterest_0.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		webkit_pinterest_0 := NewProcess(WithBrowser(webkit), WithURL("https://www.pinterest.com"), WithDuration(0))
-		webkit_pinterest_0.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		firefox_pinterest_0 := NewProcess(WithBrowser(firefox), WithURL("https://www.pinterest.com"), WithDuration(0))
-		firefox_pinterest_0.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		chromium_pinterest_0 := NewProcess(WithBrowser(chromium), WithURL("https://www.pinterest.com"), WithDuration(0))
-		chromium_pinterest_0.Run()
-	}()
-
-	go func() {
-		defer pinterestWg.Done()
-		chrome_pinterest_5 := NewProcess(WithBrowser(chrome), WithURL("https://www.pinterest.com"), WithDuration(5000))
-		chrome_pinterest_5.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		webkit_pinterest_5 := NewProcess(WithBrowser(webkit), WithURL("https://www.pinterest.com"), WithDuration(5000))
-		webkit_pinterest_5.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		firefox_pinterest_5 := NewProcess(WithBrowser(firefox), WithURL("https://www.pinterest.com"), WithDuration(5000))
-		firefox_pinterest_5.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		chromium_pinterest_5 := NewProcess(WithBrowser(chromium), WithURL("https://www.pinterest.com"), WithDuration(5000))
-		chromium_pinterest_5.Run()
-	}()
-
-	go func() {
-		defer pinterestWg.Done()
-		chrome_pinterest_10 := NewProcess(WithBrowser(chrome), WithURL("https://www.pinterest.com"), WithDuration(10000))
-		chrome_pinterest_10.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		webkit_pinterest_10 := NewProcess(WithBrowser(webkit), WithURL("https://www.pinterest.com"), WithDuration(10000))
-		webkit_pinterest_10.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		firefox_pinterest_10 := NewProcess(WithBrowser(firefox), WithURL("https://www.pinterest.com"), WithDuration(10000))
-		firefox_pinterest_10.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		chromium_pinterest_10 := NewProcess(WithBrowser(chromium), WithURL("https://www.pinterest.com"), WithDuration(10000))
-		chromium_pinterest_10.Run()
-	}()
-
-	go func() {
-		defer pinterestWg.Done()
-		chrome_pinterest_15 := NewProcess(WithBrowser(chrome), WithURL("https://www.pinterest.com"), WithDuration(15000))
-		chrome_pinterest_15.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		webkit_pinterest_15 := NewProcess(WithBrowser(webkit), WithURL("https://www.pinterest.com"), WithDuration(15000))
-		webkit_pinterest_15.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		firefox_pinterest_15 := NewProcess(WithBrowser(firefox), WithURL("https://www.pinterest.com"), WithDuration(15000))
-		firefox_pinterest_15.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		chromium_pinterest_15 := NewProcess(WithBrowser(chromium), WithURL("https://www.pinterest.com"), WithDuration(15000))
-		chromium_pinterest_15.Run()
-	}()
-
-	go func() {
-		defer pinterestWg.Done()
-		chrome_pinterest_20 := NewProcess(WithBrowser(chrome), WithURL("https://www.pinterest.com"), WithDuration(20000))
-		chrome_pinterest_20.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		webkit_pinterest_20 := NewProcess(WithBrowser(webkit), WithURL("https://www.pinterest.com"), WithDuration(20000))
-		webkit_pinterest_20.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		firefox_pinterest_20 := NewProcess(WithBrowser(firefox), WithURL("https://www.pinterest.com"), WithDuration(20000))
-		firefox_pinterest_20.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		chromium_pinterest_20 := NewProcess(WithBrowser(chromium), WithURL("https://www.pinterest.com"), WithDuration(20000))
-		chromium_pinterest_20.Run()
-	}()
-
-	go func() {
-		defer pinterestWg.Done()
-		chrome_pinterest_25 := NewProcess(WithBrowser(chrome), WithURL("https://www.pinterest.com"), WithDuration(25000))
-		chrome_pinterest_25.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		webkit_pinterest_25 := NewProcess(WithBrowser(webkit), WithURL("https://www.pinterest.com"), WithDuration(25000))
-		webkit_pinterest_25.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		firefox_pinterest_25 := NewProcess(WithBrowser(firefox), WithURL("https://www.pinterest.com"), WithDuration(25000))
-		firefox_pinterest_25.Run()
-	}()
-	go func() {
-		defer pinterestWg.Done()
-		chromium_pinterest_25 := NewProcess(WithBrowser(chromium), WithURL("https://www.pinterest.com"), WithDuration(25000))
-		chromium_pinterest_25.Run()
-	}()
-
-	pinterestWg.Wait()
-	fmt.Println("PINTEREST completed!")
-
-	// -- WEBSITE: FANDOM -- //
-	fmt.Println("Processing FANDOM...")
-	var fandomWg sync.WaitGroup
-	fandomWg.Add(24)
-
-	go func() {
-		defer fandomWg.Done()
-		chrome_fandom_0 := NewProcess(WithBrowser(chrome), WithURL("https://www.fandom.com"), WithDuration(0))
-		chrome_fandom_0.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		webkit_fandom_0 := NewProcess(WithBrowser(webkit), WithURL("https://www.fandom.com"), WithDuration(0))
-		webkit_fandom_0.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		firefox_fandom_0 := NewProcess(WithBrowser(firefox), WithURL("https://www.fandom.com"), WithDuration(0))
-		firefox_fandom_0.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		chromium_fandom_0 := NewProcess(WithBrowser(chromium), WithURL("https://www.fandom.com"), WithDuration(0))
-		chromium_fandom_0.Run()
-	}()
-
-	go func() {
-		defer fandomWg.Done()
-		chrome_fandom_5 := NewProcess(WithBrowser(chrome), WithURL("https://www.fandom.com"), WithDuration(5000))
-		chrome_fandom_5.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		webkit_fandom_5 := NewProcess(WithBrowser(webkit), WithURL("https://www.fandom.com"), WithDuration(5000))
-		webkit_fandom_5.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		firefox_fandom_5 := NewProcess(WithBrowser(firefox), WithURL("https://www.fandom.com"), WithDuration(5000))
-		firefox_fandom_5.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		chromium_fandom_5 := NewProcess(WithBrowser(chromium), WithURL("https://www.fandom.com"), WithDuration(5000))
-		chromium_fandom_5.Run()
-	}()
-
-	go func() {
-		defer fandomWg.Done()
-		chrome_fandom_10 := NewProcess(WithBrowser(chrome), WithURL("https://www.fandom.com"), WithDuration(10000))
-		chrome_fandom_10.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		webkit_fandom_10 := NewProcess(WithBrowser(webkit), WithURL("https://www.fandom.com"), WithDuration(10000))
-		webkit_fandom_10.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		firefox_fandom_10 := NewProcess(WithBrowser(firefox), WithURL("https://www.fandom.com"), WithDuration(10000))
-		firefox_fandom_10.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		chromium_fandom_10 := NewProcess(WithBrowser(chromium), WithURL("https://www.fandom.com"), WithDuration(10000))
-		chromium_fandom_10.Run()
-	}()
-
-	go func() {
-		defer fandomWg.Done()
-		chrome_fandom_15 := NewProcess(WithBrowser(chrome), WithURL("https://www.fandom.com"), WithDuration(15000))
-		chrome_fandom_15.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		webkit_fandom_15 := NewProcess(WithBrowser(webkit), WithURL("https://www.fandom.com"), WithDuration(15000))
-		webkit_fandom_15.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		firefox_fandom_15 := NewProcess(WithBrowser(firefox), WithURL("https://www.fandom.com"), WithDuration(15000))
-		firefox_fandom_15.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		chromium_fandom_15 := NewProcess(WithBrowser(chromium), WithURL("https://www.fandom.com"), WithDuration(15000))
-		chromium_fandom_15.Run()
-	}()
-
-	go func() {
-		defer fandomWg.Done()
-		chrome_fandom_20 := NewProcess(WithBrowser(chrome), WithURL("https://www.fandom.com"), WithDuration(20000))
-		chrome_fandom_20.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		webkit_fandom_20 := NewProcess(WithBrowser(webkit), WithURL("https://www.fandom.com"), WithDuration(20000))
-		webkit_fandom_20.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		firefox_fandom_20 := NewProcess(WithBrowser(firefox), WithURL("https://www.fandom.com"), WithDuration(20000))
-		firefox_fandom_20.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		chromium_fandom_20 := NewProcess(WithBrowser(chromium), WithURL("https://www.fandom.com"), WithDuration(20000))
-		chromium_fandom_20.Run()
-	}()
-
-	go func() {
-		defer fandomWg.Done()
-		chrome_fandom_25 := NewProcess(WithBrowser(chrome), WithURL("https://www.fandom.com"), WithDuration(25000))
-		chrome_fandom_25.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		webkit_fandom_25 := NewProcess(WithBrowser(webkit), WithURL("https://www.fandom.com"), WithDuration(25000))
-		webkit_fandom_25.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		firefox_fandom_25 := NewProcess(WithBrowser(firefox), WithURL("https://www.fandom.com"), WithDuration(25000))
-		firefox_fandom_25.Run()
-	}()
-	go func() {
-		defer fandomWg.Done()
-		chromium_fandom_25 := NewProcess(WithBrowser(chromium), WithURL("https://www.fandom.com"), WithDuration(25000))
-		chromium_fandom_25.Run()
-	}()
-
-	fandomWg.Wait()
-	fmt.Println("FANDOM completed!")
-
-	// -- WEBSITE: PORNHUB -- //
-	fmt.Println("Processing PORNHUB...")
-	var pornhubWg sync.WaitGroup
-	pornhubWg.Add(24)
-
-	go func() {
-		defer pornhubWg.Done()
-		chrome_pornhub_0 := NewProcess(WithBrowser(chrome), WithURL("https://www.pornhub.com"), WithDuration(0))
-		chrome_pornhub_0.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		webkit_pornhub_0 := NewProcess(WithBrowser(webkit), WithURL("https://www.pornhub.com"), WithDuration(0))
-		webkit_pornhub_0.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		firefox_pornhub_0 := NewProcess(WithBrowser(firefox), WithURL("https://www.pornhub.com"), WithDuration(0))
-		firefox_pornhub_0.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		chromium_pornhub_0 := NewProcess(WithBrowser(chromium), WithURL("https://www.pornhub.com"), WithDuration(0))
-		chromium_pornhub_0.Run()
-	}()
-
-	go func() {
-		defer pornhubWg.Done()
-		chrome_pornhub_5 := NewProcess(WithBrowser(chrome), WithURL("https://www.pornhub.com"), WithDuration(5000))
-		chrome_pornhub_5.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		webkit_pornhub_5 := NewProcess(WithBrowser(webkit), WithURL("https://www.pornhub.com"), WithDuration(5000))
-		webkit_pornhub_5.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		firefox_pornhub_5 := NewProcess(WithBrowser(firefox), WithURL("https://www.pornhub.com"), WithDuration(5000))
-		firefox_pornhub_5.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		chromium_pornhub_5 := NewProcess(WithBrowser(chromium), WithURL("https://www.pornhub.com"), WithDuration(5000))
-		chromium_pornhub_5.Run()
-	}()
-
-	go func() {
-		defer pornhubWg.Done()
-		chrome_pornhub_10 := NewProcess(WithBrowser(chrome), WithURL("https://www.pornhub.com"), WithDuration(10000))
-		chrome_pornhub_10.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		webkit_pornhub_10 := NewProcess(WithBrowser(webkit), WithURL("https://www.pornhub.com"), WithDuration(10000))
-		webkit_pornhub_10.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		firefox_pornhub_10 := NewProcess(WithBrowser(firefox), WithURL("https://www.pornhub.com"), WithDuration(10000))
-		firefox_pornhub_10.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		chromium_pornhub_10 := NewProcess(WithBrowser(chromium), WithURL("https://www.pornhub.com"), WithDuration(10000))
-		chromium_pornhub_10.Run()
-	}()
-
-	go func() {
-		defer pornhubWg.Done()
-		chrome_pornhub_15 := NewProcess(WithBrowser(chrome), WithURL("https://www.pornhub.com"), WithDuration(15000))
-		chrome_pornhub_15.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		webkit_pornhub_15 := NewProcess(WithBrowser(webkit), WithURL("https://www.pornhub.com"), WithDuration(15000))
-		webkit_pornhub_15.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		firefox_pornhub_15 := NewProcess(WithBrowser(firefox), WithURL("https://www.pornhub.com"), WithDuration(15000))
-		firefox_pornhub_15.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		chromium_pornhub_15 := NewProcess(WithBrowser(chromium), WithURL("https://www.pornhub.com"), WithDuration(15000))
-		chromium_pornhub_15.Run()
-	}()
-
-	go func() {
-		defer pornhubWg.Done()
-		chrome_pornhub_20 := NewProcess(WithBrowser(chrome), WithURL("https://www.pornhub.com"), WithDuration(20000))
-		chrome_pornhub_20.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		webkit_pornhub_20 := NewProcess(WithBrowser(webkit), WithURL("https://www.pornhub.com"), WithDuration(20000))
-		webkit_pornhub_20.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		firefox_pornhub_20 := NewProcess(WithBrowser(firefox), WithURL("https://www.pornhub.com"), WithDuration(20000))
-		firefox_pornhub_20.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		chromium_pornhub_20 := NewProcess(WithBrowser(chromium), WithURL("https://www.pornhub.com"), WithDuration(20000))
-		chromium_pornhub_20.Run()
-	}()
-
-	go func() {
-		defer pornhubWg.Done()
-		chrome_pornhub_25 := NewProcess(WithBrowser(chrome), WithURL("https://www.pornhub.com"), WithDuration(25000))
-		chrome_pornhub_25.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		webkit_pornhub_25 := NewProcess(WithBrowser(webkit), WithURL("https://www.pornhub.com"), WithDuration(25000))
-		webkit_pornhub_25.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		firefox_pornhub_25 := NewProcess(WithBrowser(firefox), WithURL("https://www.pornhub.com"), WithDuration(25000))
-		firefox_pornhub_25.Run()
-	}()
-	go func() {
-		defer pornhubWg.Done()
-		chromium_pornhub_25 := NewProcess(WithBrowser(chromium), WithURL("https://www.pornhub.com"), WithDuration(25000))
-		chromium_pornhub_25.Run()
-	}()
-
-	pornhubWg.Wait()
-	fmt.Println("PORNHUB completed!")
-
-	// -- WEBSITE: SOAP2DAY -- //
-	fmt.Println("Processing SOAP2DAY...")
-	var soap2dayWg sync.WaitGroup
-	soap2dayWg.Add(24)
-
-	go func() {
-		defer soap2dayWg.Done()
-		chrome_soap2day_0 := NewProcess(WithBrowser(chrome), WithURL("https://soap2day.to"), WithDuration(0))
-		chrome_soap2day_0.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		webkit_soap2day_0 := NewProcess(WithBrowser(webkit), WithURL("https://soap2day.to"), WithDuration(0))
-		webkit_soap2day_0.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		firefox_soap2day_0 := NewProcess(WithBrowser(firefox), WithURL("https://soap2day.to"), WithDuration(0))
-		firefox_soap2day_0.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		chromium_soap2day_0 := NewProcess(WithBrowser(chromium), WithURL("https://soap2day.to"), WithDuration(0))
-		chromium_soap2day_0.Run()
-	}()
-
-	go func() {
-		defer soap2dayWg.Done()
-		chrome_soap2day_5 := NewProcess(WithBrowser(chrome), WithURL("https://soap2day.to"), WithDuration(5000))
-		chrome_soap2day_5.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		webkit_soap2day_5 := NewProcess(WithBrowser(webkit), WithURL("https://soap2day.to"), WithDuration(5000))
-		webkit_soap2day_5.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		firefox_soap2day_5 := NewProcess(WithBrowser(firefox), WithURL("https://soap2day.to"), WithDuration(5000))
-		firefox_soap2day_5.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		chromium_soap2day_5 := NewProcess(WithBrowser(chromium), WithURL("https://soap2day.to"), WithDuration(5000))
-		chromium_soap2day_5.Run()
-	}()
-
-	go func() {
-		defer soap2dayWg.Done()
-		chrome_soap2day_10 := NewProcess(WithBrowser(chrome), WithURL("https://soap2day.to"), WithDuration(10000))
-		chrome_soap2day_10.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		webkit_soap2day_10 := NewProcess(WithBrowser(webkit), WithURL("https://soap2day.to"), WithDuration(10000))
-		webkit_soap2day_10.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		firefox_soap2day_10 := NewProcess(WithBrowser(firefox), WithURL("https://soap2day.to"), WithDuration(10000))
-		firefox_soap2day_10.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		chromium_soap2day_10 := NewProcess(WithBrowser(chromium), WithURL("https://soap2day.to"), WithDuration(10000))
-		chromium_soap2day_10.Run()
-	}()
-
-	go func() {
-		defer soap2dayWg.Done()
-		chrome_soap2day_15 := NewProcess(WithBrowser(chrome), WithURL("https://soap2day.to"), WithDuration(15000))
-		chrome_soap2day_15.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		webkit_soap2day_15 := NewProcess(WithBrowser(webkit), WithURL("https://soap2day.to"), WithDuration(15000))
-		webkit_soap2day_15.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		firefox_soap2day_15 := NewProcess(WithBrowser(firefox), WithURL("https://soap2day.to"), WithDuration(15000))
-		firefox_soap2day_15.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		chromium_soap2day_15 := NewProcess(WithBrowser(chromium), WithURL("https://soap2day.to"), WithDuration(15000))
-		chromium_soap2day_15.Run()
-	}()
-
-	go func() {
-		defer soap2dayWg.Done()
-		chrome_soap2day_20 := NewProcess(WithBrowser(chrome), WithURL("https://soap2day.to"), WithDuration(20000))
-		chrome_soap2day_20.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		webkit_soap2day_20 := NewProcess(WithBrowser(webkit), WithURL("https://soap2day.to"), WithDuration(20000))
-		webkit_soap2day_20.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		firefox_soap2day_20 := NewProcess(WithBrowser(firefox), WithURL("https://soap2day.to"), WithDuration(20000))
-		firefox_soap2day_20.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		chromium_soap2day_20 := NewProcess(WithBrowser(chromium), WithURL("https://soap2day.to"), WithDuration(20000))
-		chromium_soap2day_20.Run()
-	}()
-
-	go func() {
-		defer soap2dayWg.Done()
-		chrome_soap2day_25 := NewProcess(WithBrowser(chrome), WithURL("https://soap2day.to"), WithDuration(25000))
-		chrome_soap2day_25.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		webkit_soap2day_25 := NewProcess(WithBrowser(webkit), WithURL("https://soap2day.to"), WithDuration(25000))
-		webkit_soap2day_25.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		firefox_soap2day_25 := NewProcess(WithBrowser(firefox), WithURL("https://soap2day.to"), WithDuration(25000))
-		firefox_soap2day_25.Run()
-	}()
-	go func() {
-		defer soap2dayWg.Done()
-		chromium_soap2day_25 := NewProcess(WithBrowser(chromium), WithURL("https://soap2day.to"), WithDuration(25000))
-		chromium_soap2day_25.Run()
-	}()
-
-	soap2dayWg.Wait()
-	fmt.Println("SOAP2DAY completed!")
-
-	// -- WEBSITE: XVIDEOS -- //
-	fmt.Println("Processing XVIDEOS...")
-	var xvideosWg sync.WaitGroup
-	xvideosWg.Add(24)
-
-	go func() {
-		defer xvideosWg.Done()
-		chrome_xvideos_0 := NewProcess(WithBrowser(chrome), WithURL("https://www.xvideos.com"), WithDuration(0))
-		chrome_xvideos_0.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		webkit_xvideos_0 := NewProcess(WithBrowser(webkit), WithURL("https://www.xvideos.com"), WithDuration(0))
-		webkit_xvideos_0.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		firefox_xvideos_0 := NewProcess(WithBrowser(firefox), WithURL("https://www.xvideos.com"), WithDuration(0))
-		firefox_xvideos_0.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		chromium_xvideos_0 := NewProcess(WithBrowser(chromium), WithURL("https://www.xvideos.com"), WithDuration(0))
-		chromium_xvideos_0.Run()
-	}()
-
-	go func() {
-		defer xvideosWg.Done()
-		chrome_xvideos_5 := NewProcess(WithBrowser(chrome), WithURL("https://www.xvideos.com"), WithDuration(5000))
-		chrome_xvideos_5.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		webkit_xvideos_5 := NewProcess(WithBrowser(webkit), WithURL("https://www.xvideos.com"), WithDuration(5000))
-		webkit_xvideos_5.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		firefox_xvideos_5 := NewProcess(WithBrowser(firefox), WithURL("https://www.xvideos.com"), WithDuration(5000))
-		firefox_xvideos_5.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		chromium_xvideos_5 := NewProcess(WithBrowser(chromium), WithURL("https://www.xvideos.com"), WithDuration(5000))
-		chromium_xvideos_5.Run()
-	}()
-
-	go func() {
-		defer xvideosWg.Done()
-		chrome_xvideos_10 := NewProcess(WithBrowser(chrome), WithURL("https://www.xvideos.com"), WithDuration(10000))
-		chrome_xvideos_10.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		webkit_xvideos_10 := NewProcess(WithBrowser(webkit), WithURL("https://www.xvideos.com"), WithDuration(10000))
-		webkit_xvideos_10.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		firefox_xvideos_10 := NewProcess(WithBrowser(firefox), WithURL("https://www.xvideos.com"), WithDuration(10000))
-		firefox_xvideos_10.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		chromium_xvideos_10 := NewProcess(WithBrowser(chromium), WithURL("https://www.xvideos.com"), WithDuration(10000))
-		chromium_xvideos_10.Run()
-	}()
-
-	go func() {
-		defer xvideosWg.Done()
-		chrome_xvideos_15 := NewProcess(WithBrowser(chrome), WithURL("https://www.xvideos.com"), WithDuration(15000))
-		chrome_xvideos_15.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		webkit_xvideos_15 := NewProcess(WithBrowser(webkit), WithURL("https://www.xvideos.com"), WithDuration(15000))
-		webkit_xvideos_15.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		firefox_xvideos_15 := NewProcess(WithBrowser(firefox), WithURL("https://www.xvideos.com"), WithDuration(15000))
-		firefox_xvideos_15.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		chromium_xvideos_15 := NewProcess(WithBrowser(chromium), WithURL("https://www.xvideos.com"), WithDuration(15000))
-		chromium_xvideos_15.Run()
-	}()
-
-	go func() {
-		defer xvideosWg.Done()
-		chrome_xvideos_20 := NewProcess(WithBrowser(chrome), WithURL("https://www.xvideos.com"), WithDuration(20000))
-		chrome_xvideos_20.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		webkit_xvideos_20 := NewProcess(WithBrowser(webkit), WithURL("https://www.xvideos.com"), WithDuration(20000))
-		webkit_xvideos_20.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		firefox_xvideos_20 := NewProcess(WithBrowser(firefox), WithURL("https://www.xvideos.com"), WithDuration(20000))
-		firefox_xvideos_20.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		chromium_xvideos_20 := NewProcess(WithBrowser(chromium), WithURL("https://www.xvideos.com"), WithDuration(20000))
-		chromium_xvideos_20.Run()
-	}()
-
-	go func() {
-		defer xvideosWg.Done()
-		chrome_xvideos_25 := NewProcess(WithBrowser(chrome), WithURL("https://www.xvideos.com"), WithDuration(25000))
-		chrome_xvideos_25.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		webkit_xvideos_25 := NewProcess(WithBrowser(webkit), WithURL("https://www.xvideos.com"), WithDuration(25000))
-		webkit_xvideos_25.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		firefox_xvideos_25 := NewProcess(WithBrowser(firefox), WithURL("https://www.xvideos.com"), WithDuration(25000))
-		firefox_xvideos_25.Run()
-	}()
-	go func() {
-		defer xvideosWg.Done()
-		chromium_xvideos_25 := NewProcess(WithBrowser(chromium), WithURL("https://www.xvideos.com"), WithDuration(25000))
-		chromium_xvideos_25.Run()
-	}()
-
-	xvideosWg.Wait()
-	fmt.Println("XVIDEOS completed!")
-
-	// -- WEBSITE: FASTDOWNLOAD -- //
-	fmt.Println("Processing FASTDOWNLOAD...")
-	var fastdownloadWg sync.WaitGroup
-	fastdownloadWg.Add(24)
-
-	go func() {
-		defer fastdownloadWg.Done()
-		chrome_fastdownload_0 := NewProcess(WithBrowser(chrome), WithURL("https://www.fastdownload.com"), WithDuration(0))
-		chrome_fastdownload_0.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		webkit_fastdownload_0 := NewProcess(WithBrowser(webkit), WithURL("https://www.fastdownload.com"), WithDuration(0))
-		webkit_fastdownload_0.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		firefox_fastdownload_0 := NewProcess(WithBrowser(firefox), WithURL("https://www.fastdownload.com"), WithDuration(0))
-		firefox_fastdownload_0.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		chromium_fastdownload_0 := NewProcess(WithBrowser(chromium), WithURL("https://www.fastdownload.com"), WithDuration(0))
-		chromium_fastdownload_0.Run()
-	}()
-
-	go func() {
-		defer fastdownloadWg.Done()
-		chrome_fastdownload_5 := NewProcess(WithBrowser(chrome), WithURL("https://www.fastdownload.com"), WithDuration(5000))
-		chrome_fastdownload_5.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		webkit_fastdownload_5 := NewProcess(WithBrowser(webkit), WithURL("https://www.fastdownload.com"), WithDuration(5000))
-		webkit_fastdownload_5.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		firefox_fastdownload_5 := NewProcess(WithBrowser(firefox), WithURL("https://www.fastdownload.com"), WithDuration(5000))
-		firefox_fastdownload_5.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		chromium_fastdownload_5 := NewProcess(WithBrowser(chromium), WithURL("https://www.fastdownload.com"), WithDuration(5000))
-		chromium_fastdownload_5.Run()
-	}()
-
-	go func() {
-		defer fastdownloadWg.Done()
-		chrome_fastdownload_10 := NewProcess(WithBrowser(chrome), WithURL("https://www.fastdownload.com"), WithDuration(10000))
-		chrome_fastdownload_10.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		webkit_fastdownload_10 := NewProcess(WithBrowser(webkit), WithURL("https://www.fastdownload.com"), WithDuration(10000))
-		webkit_fastdownload_10.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		firefox_fastdownload_10 := NewProcess(WithBrowser(firefox), WithURL("https://www.fastdownload.com"), WithDuration(10000))
-		firefox_fastdownload_10.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		chromium_fastdownload_10 := NewProcess(WithBrowser(chromium), WithURL("https://www.fastdownload.com"), WithDuration(10000))
-		chromium_fastdownload_10.Run()
-	}()
-
-	go func() {
-		defer fastdownloadWg.Done()
-		chrome_fastdownload_15 := NewProcess(WithBrowser(chrome), WithURL("https://www.fastdownload.com"), WithDuration(15000))
-		chrome_fastdownload_15.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		webkit_fastdownload_15 := NewProcess(WithBrowser(webkit), WithURL("https://www.fastdownload.com"), WithDuration(15000))
-		webkit_fastdownload_15.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		firefox_fastdownload_15 := NewProcess(WithBrowser(firefox), WithURL("https://www.fastdownload.com"), WithDuration(15000))
-		firefox_fastdownload_15.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		chromium_fastdownload_15 := NewProcess(WithBrowser(chromium), WithURL("https://www.fastdownload.com"), WithDuration(15000))
-		chromium_fastdownload_15.Run()
-	}()
-
-	go func() {
-		defer fastdownloadWg.Done()
-		chrome_fastdownload_20 := NewProcess(WithBrowser(chrome), WithURL("https://www.fastdownload.com"), WithDuration(20000))
-		chrome_fastdownload_20.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		webkit_fastdownload_20 := NewProcess(WithBrowser(webkit), WithURL("https://www.fastdownload.com"), WithDuration(20000))
-		webkit_fastdownload_20.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		firefox_fastdownload_20 := NewProcess(WithBrowser(firefox), WithURL("https://www.fastdownload.com"), WithDuration(20000))
-		firefox_fastdownload_20.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		chromium_fastdownload_20 := NewProcess(WithBrowser(chromium), WithURL("https://www.fastdownload.com"), WithDuration(20000))
-		chromium_fastdownload_20.Run()
-	}()
-
-	go func() {
-		defer fastdownloadWg.Done()
-		chrome_fastdownload_25 := NewProcess(WithBrowser(chrome), WithURL("https://www.fastdownload.com"), WithDuration(25000))
-		chrome_fastdownload_25.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		webkit_fastdownload_25 := NewProcess(WithBrowser(webkit), WithURL("https://www.fastdownload.com"), WithDuration(25000))
-		webkit_fastdownload_25.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		firefox_fastdownload_25 := NewProcess(WithBrowser(firefox), WithURL("https://www.fastdownload.com"), WithDuration(25000))
-		firefox_fastdownload_25.Run()
-	}()
-	go func() {
-		defer fastdownloadWg.Done()
-		chromium_fastdownload_25 := NewProcess(WithBrowser(chromium), WithURL("https://www.fastdownload.com"), WithDuration(25000))
-		chromium_fastdownload_25.Run()
-	}()
-
-	fastdownloadWg.Wait()
-	fmt.Println("FASTDOWNLOAD completed!")
-
-	// -- WEBSITE: ENDACE -- //
-	fmt.Println("Processing ENDACE...")
-	var endaceWg sync.WaitGroup
-	endaceWg.Add(24)
-
-	go func() {
-		defer endaceWg.Done()
-		chrome_endace_0 := NewProcess(WithBrowser(chrome), WithURL("https://www.endace.com"), WithDuration(0))
-		chrome_endace_0.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		webkit_endace_0 := NewProcess(WithBrowser(webkit), WithURL("https://www.endace.com"), WithDuration(0))
-		webkit_endace_0.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		firefox_endace_0 := NewProcess(WithBrowser(firefox), WithURL("https://www.endace.com"), WithDuration(0))
-		firefox_endace_0.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		chromium_endace_0 := NewProcess(WithBrowser(chromium), WithURL("https://www.endace.com"), WithDuration(0))
-		chromium_endace_0.Run()
-	}()
-
-	go func() {
-		defer endaceWg.Done()
-		chrome_endace_5 := NewProcess(WithBrowser(chrome), WithURL("https://www.endace.com"), WithDuration(5000))
-		chrome_endace_5.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		webkit_endace_5 := NewProcess(WithBrowser(webkit), WithURL("https://www.endace.com"), WithDuration(5000))
-		webkit_endace_5.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		firefox_endace_5 := NewProcess(WithBrowser(firefox), WithURL("https://www.endace.com"), WithDuration(5000))
-		firefox_endace_5.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		chromium_endace_5 := NewProcess(WithBrowser(chromium), WithURL("https://www.endace.com"), WithDuration(5000))
-		chromium_endace_5.Run()
-	}()
-
-	go func() {
-		defer endaceWg.Done()
-		chrome_endace_10 := NewProcess(WithBrowser(chrome), WithURL("https://www.endace.com"), WithDuration(10000))
-		chrome_endace_10.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		webkit_endace_10 := NewProcess(WithBrowser(webkit), WithURL("https://www.endace.com"), WithDuration(10000))
-		webkit_endace_10.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		firefox_endace_10 := NewProcess(WithBrowser(firefox), WithURL("https://www.endace.com"), WithDuration(10000))
-		firefox_endace_10.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		chromium_endace_10 := NewProcess(WithBrowser(chromium), WithURL("https://www.endace.com"), WithDuration(10000))
-		chromium_endace_10.Run()
-	}()
-
-	go func() {
-		defer endaceWg.Done()
-		chrome_endace_15 := NewProcess(WithBrowser(chrome), WithURL("https://www.endace.com"), WithDuration(15000))
-		chrome_endace_15.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		webkit_endace_15 := NewProcess(WithBrowser(webkit), WithURL("https://www.endace.com"), WithDuration(15000))
-		webkit_endace_15.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		firefox_endace_15 := NewProcess(WithBrowser(firefox), WithURL("https://www.endace.com"), WithDuration(15000))
-		firefox_endace_15.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		chromium_endace_15 := NewProcess(WithBrowser(chromium), WithURL("https://www.endace.com"), WithDuration(15000))
-		chromium_endace_15.Run()
-	}()
-
-	go func() {
-		defer endaceWg.Done()
-		chrome_endace_20 := NewProcess(WithBrowser(chrome), WithURL("https://www.endace.com"), WithDuration(20000))
-		chrome_endace_20.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		webkit_endace_20 := NewProcess(WithBrowser(webkit), WithURL("https://www.endace.com"), WithDuration(20000))
-		webkit_endace_20.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		firefox_endace_20 := NewProcess(WithBrowser(firefox), WithURL("https://www.endace.com"), WithDuration(20000))
-		firefox_endace_20.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		chromium_endace_20 := NewProcess(WithBrowser(chromium), WithURL("https://www.endace.com"), WithDuration(20000))
-		chromium_endace_20.Run()
-	}()
-
-	go func() {
-		defer endaceWg.Done()
-		chrome_endace_25 := NewProcess(WithBrowser(chrome), WithURL("https://www.endace.com"), WithDuration(25000))
-		chrome_endace_25.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		webkit_endace_25 := NewProcess(WithBrowser(webkit), WithURL("https://www.endace.com"), WithDuration(25000))
-		webkit_endace_25.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		firefox_endace_25 := NewProcess(WithBrowser(firefox), WithURL("https://www.endace.com"), WithDuration(25000))
-		firefox_endace_25.Run()
-	}()
-	go func() {
-		defer endaceWg.Done()
-		chromium_endace_25 := NewProcess(WithBrowser(chromium), WithURL("https://www.endace.com"), WithDuration(25000))
-		chromium_endace_25.Run()
-	}()
-
-	endaceWg.Wait()
-	fmt.Println("ENDACE completed!")
+	// Ctrl-C cancels the sweep; running crawls close their pages and save partial results.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Read the study design from the matrix file.
+	matrix, err := ReadMatrix(MATRIXFILE)
+	if err != nil {
+		return err
+	}
+
+	// Retry policy for navigation failures, the default is used if the file is missing.
+	retry, err := crawler.ReadRetryPolicy(crawler.RETRYFILE)
+	if err != nil {
+		fmt.Printf("%v, using default retry policy\n", err)
+		retry = crawler.DefaultRetryPolicy()
+	}
+
+	// Tracker lists are loaded once, every report records their version.
+	trackers, err := crawler.ReadTrackerLists(crawler.TRACKERFILE)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Tracker lists: %s\n", trackers.Version)
+
+	catalog, err := crawler.ReadCookieCatalog(crawler.COOKIECATALOGFILE)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Cookie catalog: %s\n", catalog.Version)
+
+	preload, err := crawler.ReadHSTSPreloadList(crawler.HSTSPRELOADFILE)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("HSTS preload list: %s\n", preload.Version)
+
+	// One HTTP client for every process, so header audits reuse connections.
+	httpClient, err := crawler.ReadHTTPClient(crawler.HTTPCLIENTFILE)
+	if err != nil {
+		fmt.Printf("%v, using default HTTP client\n", err)
+		httpClient, err = crawler.NewHTTPClient(crawler.DefaultHTTPClientConfig())
+		if err != nil {
+			return err
+		}
+	}
+
+	// One driver and one browser per engine are shared by every process.
+	pool, err := crawler.NewBrowserPool(defaultProcessOptions().hidden, false)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	// Each site finishes all of its processes before moving to the next,
+	// with no more processes running at once than the matrix allows.
+	scheduler := matrix.NewScheduler()
+	groups := matrix.Expand(WithPool(pool), WithRetryPolicy(&retry), WithTrackers(trackers), WithCatalog(catalog),
+		WithHSTSPreload(preload), WithHTTPClient(httpClient))
+	scheduler.RunGroups(ctx, groups)
+
+	completed, failed, total := scheduler.Progress()
+	fmt.Printf("Processes completed: %d/%d (%d failed)\n", completed, total, failed)
+
+	if ctx.Err() != nil {
+		fmt.Println("Sweep cancelled.")
+		return ctx.Err()
+	}
 
 	fmt.Println("All websites completed successfully!")
 	return nil
@@ -1546,6 +428,7 @@ func GenerateTotalsFile() {
 	sameSiteNoneRegex := regexp.MustCompile(`Total SameSite with None: (\d+)`)
 	sessionRegex := regexp.MustCompile(`Total Session Cookies: (\d+)`)
 	persistentRegex := regexp.MustCompile(`Total Persistent Cookies: (\d+)`)
+	outcomeRegex := regexp.MustCompile(`Crawl Outcome: (\S+)`)
 
 	fmt.Println("Parsing browser data...")
 
@@ -1601,6 +484,17 @@ func GenerateTotalsFile() {
 			continue
 		}
 
+		// Leave failed crawls out of the totals.
+		if matches := outcomeRegex.FindStringSubmatch(line); matches != nil {
+			outcome := crawler.CrawlOutcome{Category: crawler.OutcomeCategory(matches[1])}
+			if outcome.IsFailure() {
+				currentStats.TotalReports--
+				currentStats.FailedReports++
+				currentBrowser = ""
+			}
+			continue
+		}
+
 		// Extract and add values
 		if matches := totalCookiesRegex.FindStringSubmatch(line); matches != nil {
 			if val, err := strconv.Atoi(matches[1]); err == nil {
@@ -1681,6 +575,7 @@ func GenerateTotalsFile() {
 	// Write Chrome totals
 	fmt.Fprintf(outFile, "CHROME:\n")
 	fmt.Fprintf(outFile, "Total Reports: %d\n", chromeStats.TotalReports)
+	fmt.Fprintf(outFile, "Failed Reports: %d\n", chromeStats.FailedReports)
 	fmt.Fprintf(outFile, "Total Cookies: %d\n", chromeStats.TotalCookies)
 	fmt.Fprintf(outFile, "First-Party Cookies: %d\n", chromeStats.TotalFirstParty)
 	fmt.Fprintf(outFile, "Third-Party Cookies: %d\n", chromeStats.TotalThirdParty)
@@ -1697,6 +592,7 @@ func GenerateTotalsFile() {
 	// Write Chromium totals
 	fmt.Fprintf(outFile, "CHROMIUM:\n")
 	fmt.Fprintf(outFile, "Total Reports: %d\n", chromiumStats.TotalReports)
+	fmt.Fprintf(outFile, "Failed Reports: %d\n", chromiumStats.FailedReports)
 	fmt.Fprintf(outFile, "Total Cookies: %d\n", chromiumStats.TotalCookies)
 	fmt.Fprintf(outFile, "First-Party Cookies: %d\n", chromiumStats.TotalFirstParty)
 	fmt.Fprintf(outFile, "Third-Party Cookies: %d\n", chromiumStats.TotalThirdParty)
@@ -1713,6 +609,7 @@ func GenerateTotalsFile() {
 	// Write Firefox totals
 	fmt.Fprintf(outFile, "FIREFOX:\n")
 	fmt.Fprintf(outFile, "Total Reports: %d\n", firefoxStats.TotalReports)
+	fmt.Fprintf(outFile, "Failed Reports: %d\n", firefoxStats.FailedReports)
 	fmt.Fprintf(outFile, "Total Cookies: %d\n", firefoxStats.TotalCookies)
 	fmt.Fprintf(outFile, "First-Party Cookies: %d\n", firefoxStats.TotalFirstParty)
 	fmt.Fprintf(outFile, "Third-Party Cookies: %d\n", firefoxStats.TotalThirdParty)
@@ -1729,6 +626,7 @@ func GenerateTotalsFile() {
 	// Write WebKit totals (instead of Safari)
 	fmt.Fprintf(outFile, "WEBKIT:\n") // Changed from SAFARI:
 	fmt.Fprintf(outFile, "Total Reports: %d\n", webkitStats.TotalReports)
+	fmt.Fprintf(outFile, "Failed Reports: %d\n", webkitStats.FailedReports)
 	fmt.Fprintf(outFile, "Total Cookies: %d\n", webkitStats.TotalCookies)
 	fmt.Fprintf(outFile, "First-Party Cookies: %d\n", webkitStats.TotalFirstParty)
 	fmt.Fprintf(outFile, "Third-Party Cookies: %d\n", webkitStats.TotalThirdParty)
@@ -0,0 +1,299 @@
+package jmppoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"privcrawler/internal/crawler"
+	"slices"
+	"strings"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Crawl Matrix: Represents the study design read from the matrix file.
+// Every site is crawled with every browser for every duration, repeated
+// 'repetitions' times, unless the site has an entry in 'overrides'.
+type CrawlMatrix struct {
+	Sites            []string                `json:"sites"`
+	Browsers         []string                `json:"browsers"`
+	Durations        []int                   `json:"durations"`
+	Repetitions      int                     `json:"repetitions"`
+	Depth            int                     `json:"depth"`
+	MaxPages         int                     `json:"maxPages"`
+	Timeout          int                     `json:"timeout"`
+	Wait             crawler.WaitStrategy    `json:"wait"`
+	SnapshotInterval int                     `json:"snapshotInterval"`
+	Consent          crawler.ConsentMode     `json:"consent"`
+	CNAME            CNAMEConfig             `json:"cname"`
+	GPC              bool                    `json:"gpc"`
+	Overrides        map[string]SiteOverride `json:"overrides"`
+	Concurrency      ConcurrencyLimits       `json:"concurrency"`
+}
+
+// Concurrency Limits: Represents how many processes may run at once,
+// in total and per browser engine. A limit of 0 means unlimited.
+type ConcurrencyLimits struct {
+	MaxWorkers int            `json:"maxWorkers"`
+	Browsers   map[string]int `json:"browsers"`
+}
+
+// CNAME Config: Represents the DNS step that finds trackers cloaked behind
+// first-party subdomains. Resolver is a DNS server (host:port), empty uses the
+// system resolver.
+type CNAMEConfig struct {
+	Enabled  bool   `json:"enabled"`
+	Resolver string `json:"resolver"`
+}
+
+// Site Override: Replaces the matrix defaults for a single site.
+// Fields left empty fall back to the matrix defaults.
+type SiteOverride struct {
+	Browsers    []string `json:"browsers"`
+	Durations   []int    `json:"durations"`
+	Repetitions int      `json:"repetitions"`
+}
+
+// Site Group: Holds every process of a single site so they can be run together.
+type SiteGroup struct {
+	URL       string
+	Name      string
+	Processes []*Process
+}
+
+// ---- Global Definitions ---- //
+
+// Matrix File: Location of the pre-configured crawl matrix.
+const MATRIXFILE string = "internal/config/matrix.json"
+
+// ---- Functions ---- //
+
+// Function: Read Matrix
+// Operation: Reads and validates the crawl matrix file at the given path.
+// Return: *CrawlMatrix, Error
+func ReadMatrix(path string) (*CrawlMatrix, error) {
+
+	// Read matrix file into data variable.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading matrix file: %v", err)
+	}
+
+	// Parse JSON into data structure.
+	var matrix CrawlMatrix
+	err = json.Unmarshal(data, &matrix)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing matrix file: %v", err)
+	}
+
+	err = matrix.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &matrix, nil
+}
+
+// Function: Validate
+// Operation: Checks the matrix for missing fields, unknown browsers, negative
+// durations and overrides for sites that are not in the matrix.
+// Return: Error
+func (m *CrawlMatrix) validate() error {
+	if len(m.Sites) == 0 {
+		return fmt.Errorf("matrix has no sites")
+	}
+	if len(m.Browsers) == 0 {
+		return fmt.Errorf("matrix has no browsers")
+	}
+	if len(m.Durations) == 0 {
+		return fmt.Errorf("matrix has no durations")
+	}
+	if m.Repetitions < 0 {
+		return fmt.Errorf("matrix repetitions cannot be negative")
+	}
+	if m.Depth < 0 || m.MaxPages < 0 {
+		return fmt.Errorf("matrix depth and maxPages cannot be negative")
+	}
+	if m.Timeout < 0 {
+		return fmt.Errorf("matrix timeout cannot be negative")
+	}
+	if m.SnapshotInterval < 0 {
+		return fmt.Errorf("matrix snapshotInterval cannot be negative")
+	}
+	if err := m.Wait.Validate(); err != nil {
+		return fmt.Errorf("matrix %v", err)
+	}
+	if err := crawler.ValidateConsentMode(m.Consent); err != nil {
+		return fmt.Errorf("matrix %v", err)
+	}
+	if m.CNAME.Resolver != "" {
+		if _, _, err := net.SplitHostPort(m.CNAME.Resolver); err != nil {
+			return fmt.Errorf("matrix cname resolver: %v", err)
+		}
+	}
+
+	err := validateBrowsers(m.Browsers)
+	if err != nil {
+		return err
+	}
+	err = validateDurations(m.Durations)
+	if err != nil {
+		return err
+	}
+
+	if m.Concurrency.MaxWorkers < 0 {
+		return fmt.Errorf("matrix maxWorkers cannot be negative")
+	}
+	for browser, limit := range m.Concurrency.Browsers {
+		if limit < 0 {
+			return fmt.Errorf("concurrency for %s cannot be negative", browser)
+		}
+		err = validateBrowsers([]string{browser})
+		if err != nil {
+			return fmt.Errorf("concurrency: %v", err)
+		}
+	}
+
+	for site, override := range m.Overrides {
+		// An override for a misspelled site would be silently ignored.
+		if !slices.Contains(m.Sites, site) {
+			return fmt.Errorf("override for %s: site is not in the matrix", site)
+		}
+		if override.Repetitions < 0 {
+			return fmt.Errorf("override for %s: repetitions cannot be negative", site)
+		}
+		err = validateBrowsers(override.Browsers)
+		if err != nil {
+			return fmt.Errorf("override for %s: %v", site, err)
+		}
+		err = validateDurations(override.Durations)
+		if err != nil {
+			return fmt.Errorf("override for %s: %v", site, err)
+		}
+	}
+
+	return nil
+}
+
+// Function: Validate Browsers
+// Operation: Checks that every browser is one the crawler can launch.
+// Return: Error
+func validateBrowsers(browsers []string) error {
+	for _, browser := range browsers {
+		switch browser {
+		case chrome, firefox, chromium, webkit:
+		default:
+			return fmt.Errorf("browser %s is not supported", browser)
+		}
+	}
+	return nil
+}
+
+// Function: Validate Durations
+// Operation: Checks that no duration is negative.
+// Return: Error
+func validateDurations(durations []int) error {
+	for _, duration := range durations {
+		if duration < 0 {
+			return fmt.Errorf("duration %d cannot be negative", duration)
+		}
+	}
+	return nil
+}
+
+// Function: Expand
+// Operation: Turns the matrix into processes, grouped by site in file order.
+// Within a site, processes are ordered by duration, then browser, then repetition.
+// Return: []SiteGroup
+func (m *CrawlMatrix) Expand(opts ...ProcessOptionsFunc) []SiteGroup {
+	groups := make([]SiteGroup, 0, len(m.Sites))
+
+	// One resolver for the sweep, so each host is looked up once.
+	var resolver *crawler.CNAMEResolver
+	if m.CNAME.Enabled {
+		resolver = crawler.NewCNAMEResolver(m.CNAME.Resolver)
+	}
+
+	for _, site := range m.Sites {
+		browsers := m.Browsers
+		durations := m.Durations
+		repetitions := m.Repetitions
+
+		// Apply per-site overrides.
+		if override, ok := m.Overrides[site]; ok {
+			if len(override.Browsers) > 0 {
+				browsers = override.Browsers
+			}
+			if len(override.Durations) > 0 {
+				durations = override.Durations
+			}
+			if override.Repetitions > 0 {
+				repetitions = override.Repetitions
+			}
+		}
+		if repetitions == 0 {
+			repetitions = 1
+		}
+
+		group := SiteGroup{
+			URL:  site,
+			Name: siteName(site),
+		}
+
+		for _, duration := range durations {
+			for _, browser := range browsers {
+				for rep := 0; rep < repetitions; rep++ {
+					processOpts := append([]ProcessOptionsFunc{
+						WithBrowser(browser),
+						WithURL(site),
+						WithDuration(duration),
+						WithDepth(m.Depth),
+						WithMaxPages(m.MaxPages),
+						WithTimeout(m.Timeout),
+						WithWaitStrategy(m.Wait),
+						WithSnapshotInterval(m.SnapshotInterval),
+						WithConsent(m.Consent),
+						WithCNAMEResolver(resolver),
+						WithGPCCompare(m.GPC),
+					}, opts...)
+					group.Processes = append(group.Processes, NewProcess(processOpts...))
+				}
+			}
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// Function: New Scheduler
+// Operation: Creates a scheduler with the concurrency limits of the matrix.
+// Return: *Scheduler
+func (m *CrawlMatrix) NewScheduler(opts ...SchedulerOptionsFunc) *Scheduler {
+	schedulerOpts := []SchedulerOptionsFunc{WithMaxWorkers(m.Concurrency.MaxWorkers)}
+	for browser, limit := range m.Concurrency.Browsers {
+		schedulerOpts = append(schedulerOpts, WithBrowserLimit(browser, limit))
+	}
+
+	return NewScheduler(append(schedulerOpts, opts...)...)
+}
+
+// Function: Site Name
+// Operation: Creates a display name from the URL, e.g. https://www.amazon.com -> AMAZON
+// Return: String
+func siteName(site string) string {
+	parsedURL, err := url.Parse(site)
+	if err != nil || parsedURL.Hostname() == "" {
+		return strings.ToUpper(site)
+	}
+
+	host := strings.TrimPrefix(parsedURL.Hostname(), "www.")
+	if dot := strings.Index(host, "."); dot > 0 {
+		host = host[:dot]
+	}
+
+	return strings.ToUpper(host)
+}
@@ -0,0 +1,187 @@
+package jmppoint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"privcrawler/internal/crawler"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Function: Valid Matrix
+// Operation: Returns a matrix that passes validation, for tests to break one field of.
+// Return: CrawlMatrix
+func validMatrix() CrawlMatrix {
+	return CrawlMatrix{
+		Sites:       []string{"https://www.amazon.com", "https://www.bbc.co.uk"},
+		Browsers:    []string{chrome, firefox},
+		Durations:   []int{2000, 5000},
+		Repetitions: 2,
+	}
+}
+
+func TestMatrixValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(m *CrawlMatrix)
+		err    string // Empty if the matrix is valid.
+	}{
+		{"valid", func(m *CrawlMatrix) {}, ""},
+		{"all engines", func(m *CrawlMatrix) { m.Browsers = []string{chrome, firefox, chromium, webkit} }, ""},
+		{"no sites", func(m *CrawlMatrix) { m.Sites = nil }, "no sites"},
+		{"no browsers", func(m *CrawlMatrix) { m.Browsers = nil }, "no browsers"},
+		{"no durations", func(m *CrawlMatrix) { m.Durations = nil }, "no durations"},
+		{"unknown browser", func(m *CrawlMatrix) { m.Browsers = []string{chrome, "edge"} }, "browser edge is not supported"},
+		{"negative repetitions", func(m *CrawlMatrix) { m.Repetitions = -1 }, "repetitions cannot be negative"},
+		{"negative duration", func(m *CrawlMatrix) { m.Durations = []int{2000, -5000} }, "duration -5000 cannot be negative"},
+		{"zero duration", func(m *CrawlMatrix) { m.Durations = []int{0} }, ""},
+		{"negative depth", func(m *CrawlMatrix) { m.Depth = -1 }, "depth and maxPages"},
+		{"negative max pages", func(m *CrawlMatrix) { m.MaxPages = -1 }, "depth and maxPages"},
+		{"negative timeout", func(m *CrawlMatrix) { m.Timeout = -1 }, "timeout cannot be negative"},
+		{"negative snapshot interval", func(m *CrawlMatrix) { m.SnapshotInterval = -1 }, "snapshotInterval"},
+		{"unknown wait mode", func(m *CrawlMatrix) { m.Wait.Mode = "forever" }, "wait mode forever"},
+		{"unknown consent mode", func(m *CrawlMatrix) { m.Consent = "ignore" }, "consent mode ignore"},
+		{"consent compare", func(m *CrawlMatrix) { m.Consent = crawler.ConsentCompare }, ""},
+		{"resolver without port", func(m *CrawlMatrix) { m.CNAME.Resolver = "1.1.1.1" }, "cname resolver"},
+		{"resolver with port", func(m *CrawlMatrix) { m.CNAME = CNAMEConfig{Enabled: true, Resolver: "1.1.1.1:53"} }, ""},
+		{"negative max workers", func(m *CrawlMatrix) { m.Concurrency.MaxWorkers = -1 }, "maxWorkers"},
+		{"negative browser limit", func(m *CrawlMatrix) { m.Concurrency.Browsers = map[string]int{firefox: -1} }, "concurrency for firefox"},
+		{"limit for unknown browser", func(m *CrawlMatrix) { m.Concurrency.Browsers = map[string]int{"edge": 1} }, "concurrency: browser edge"},
+		{"override with unknown browser", func(m *CrawlMatrix) {
+			m.Overrides = map[string]SiteOverride{"https://www.bbc.co.uk": {Browsers: []string{"safari"}}}
+		}, "override for https://www.bbc.co.uk: browser safari"},
+		{"override with negative repetitions", func(m *CrawlMatrix) {
+			m.Overrides = map[string]SiteOverride{"https://www.bbc.co.uk": {Repetitions: -2}}
+		}, "override for https://www.bbc.co.uk: repetitions"},
+		{"override with negative duration", func(m *CrawlMatrix) {
+			m.Overrides = map[string]SiteOverride{"https://www.bbc.co.uk": {Durations: []int{1000, -1}}}
+		}, "override for https://www.bbc.co.uk: duration -1"},
+		{"override for a site not in the matrix", func(m *CrawlMatrix) {
+			m.Overrides = map[string]SiteOverride{"https://www.bbc.com": {Repetitions: 3}}
+		}, "override for https://www.bbc.com: site is not in the matrix"},
+		{"valid override", func(m *CrawlMatrix) {
+			m.Overrides = map[string]SiteOverride{"https://www.bbc.co.uk": {Browsers: []string{webkit}, Durations: []int{1000}}}
+		}, ""},
+	}
+
+	for _, test := range tests {
+		matrix := validMatrix()
+		test.change(&matrix)
+
+		err := matrix.validate()
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("%s: error %v, want one containing %q", test.name, err, test.err)
+		}
+	}
+}
+
+func TestReadMatrix(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	matrix, err := ReadMatrix(write("valid.json", `{"sites": ["https://www.amazon.com"], "browsers": ["webkit"],
+		"durations": [1000], "concurrency": {"maxWorkers": 2, "browsers": {"webkit": 1}}}`))
+	if err != nil {
+		t.Fatalf("ReadMatrix: %v", err)
+	}
+	if matrix.Concurrency.Browsers[webkit] != 1 || matrix.Concurrency.MaxWorkers != 2 {
+		t.Errorf("concurrency = %+v", matrix.Concurrency)
+	}
+
+	tests := []struct {
+		path string
+		err  string
+	}{
+		{filepath.Join(dir, "missing.json"), "error reading matrix file"},
+		{write("broken.json", `{"sites": [`), "error parsing matrix file"},
+		{write("invalid.json", `{"sites": ["https://www.amazon.com"], "browsers": ["edge"], "durations": [1000]}`), "browser edge"},
+	}
+	for _, test := range tests {
+		if _, err := ReadMatrix(test.path); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("ReadMatrix(%s) error %v, want one containing %q", filepath.Base(test.path), err, test.err)
+		}
+	}
+}
+
+func TestMatrixExpand(t *testing.T) {
+	matrix := validMatrix()
+	matrix.Depth = 1
+	matrix.Overrides = map[string]SiteOverride{
+		"https://www.bbc.co.uk": {Browsers: []string{webkit}, Repetitions: 3},
+	}
+
+	groups := matrix.Expand(WithHidden(false))
+
+	tests := []struct {
+		url       string
+		name      string
+		processes []string // browser/duration of each process, in order.
+	}{
+		{"https://www.amazon.com", "AMAZON", []string{
+			"chrome/2000", "chrome/2000", "firefox/2000", "firefox/2000",
+			"chrome/5000", "chrome/5000", "firefox/5000", "firefox/5000",
+		}},
+		{"https://www.bbc.co.uk", "BBC", []string{
+			"webkit/2000", "webkit/2000", "webkit/2000",
+			"webkit/5000", "webkit/5000", "webkit/5000",
+		}},
+	}
+
+	if len(groups) != len(tests) {
+		t.Fatalf("Expand gave %d groups, want %d", len(groups), len(tests))
+	}
+	for i, test := range tests {
+		group := groups[i]
+		if group.URL != test.url || group.Name != test.name {
+			t.Errorf("group %d = %s %s, want %s %s", i, group.URL, group.Name, test.url, test.name)
+		}
+
+		var processes []string
+		for _, process := range group.Processes {
+			processes = append(processes, fmt.Sprintf("%s/%d", process.GetBrowser(), process.GetDuration()))
+			if process.GetURL() != test.url || process.IsHidden() || process.options.depth != 1 {
+				t.Errorf("%s: process options %+v", test.url, process.options)
+			}
+		}
+		if !reflect.DeepEqual(processes, test.processes) {
+			t.Errorf("%s: processes %v, want %v", test.url, processes, test.processes)
+		}
+	}
+
+	// Repetitions left at 0 run once.
+	matrix = validMatrix()
+	matrix.Repetitions = 0
+	if groups := matrix.Expand(); len(groups[0].Processes) != len(matrix.Browsers)*len(matrix.Durations) {
+		t.Errorf("0 repetitions gave %d processes", len(groups[0].Processes))
+	}
+}
+
+func TestSiteName(t *testing.T) {
+	tests := []struct {
+		site string
+		want string
+	}{
+		{"https://www.amazon.com", "AMAZON"},
+		{"https://amazon.co.uk/gp/cart", "AMAZON"},
+		{"http://news.ycombinator.com", "NEWS"},
+		{"https://localhost:8080", "LOCALHOST"},
+		{"not a url", "NOT A URL"},
+	}
+
+	for _, test := range tests {
+		if got := siteName(test.site); got != test.want {
+			t.Errorf("siteName(%q) = %q, want %q", test.site, got, test.want)
+		}
+	}
+}
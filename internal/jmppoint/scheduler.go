@@ -0,0 +1,228 @@
+package jmppoint
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ---- DATA STRUCTURES ---- //
+
+// Scheduler Options: Represents the limits for running processes.
+// A limit of 0 means unlimited.
+type SchedulerOptions struct {
+	maxWorkers    int
+	browserLimits map[string]int
+}
+
+// Scheduler: Runs processes from a FIFO queue while keeping the number of
+// running processes under the global and per-browser limits.
+type Scheduler struct {
+	options SchedulerOptions
+
+	mu               sync.Mutex
+	wg               sync.WaitGroup
+	queue            []queuedProcess
+	running          int
+	runningByBrowser map[string]int
+
+	// Runs one process, Process.Run outside of tests.
+	runProcess func(ctx context.Context, p *Process) error
+
+	// Progress counters.
+	// Expected is the number of processes announced ahead of time by RunGroups.
+	expected  int
+	submitted int
+	completed int
+	failed    int
+}
+
+// Queued Process: A process waiting for a slot, with the context it runs under.
+// Stop unregisters the wake-up on cancel once the process leaves the queue.
+type queuedProcess struct {
+	ctx     context.Context
+	process *Process
+	stop    func() bool
+}
+
+// Data type that will act as a wrapper for initializing with functions
+type SchedulerOptionsFunc func(*SchedulerOptions)
+
+func defaultSchedulerOptions() SchedulerOptions {
+	return SchedulerOptions{
+		maxWorkers:    0,
+		browserLimits: make(map[string]int),
+	}
+}
+
+// ---- FUNCTIONAL OPTIONS ---- //
+
+// WithMaxWorkers sets the global number of processes that may run at once
+func WithMaxWorkers(maxWorkers int) SchedulerOptionsFunc {
+	return func(opts *SchedulerOptions) {
+		opts.maxWorkers = maxWorkers
+	}
+}
+
+// WithBrowserLimit sets the number of processes that may run at once for one browser engine
+func WithBrowserLimit(browser string, limit int) SchedulerOptionsFunc {
+	return func(opts *SchedulerOptions) {
+		opts.browserLimits[browser] = limit
+	}
+}
+
+// ---- CONSTRUCTOR ---- //
+func NewScheduler(opts ...SchedulerOptionsFunc) *Scheduler {
+	o := defaultSchedulerOptions()
+
+	for _, fn := range opts {
+		fn(&o)
+	}
+
+	return &Scheduler{
+		options:          o,
+		runningByBrowser: make(map[string]int),
+		runProcess: func(ctx context.Context, p *Process) error {
+			return p.Run(ctx)
+		},
+	}
+}
+
+// ---- METHODS ---- //
+
+// Submit adds a process to the back of the queue and starts it when a slot is free.
+// If ctx is cancelled while the process is still queued, it is dropped as failed
+func (s *Scheduler) Submit(ctx context.Context, p *Process) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.wg.Add(1)
+	s.submitted++
+
+	// Wake the queue on cancel, in case nothing finishes to trigger a dispatch.
+	stop := context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.dispatch()
+	})
+
+	s.queue = append(s.queue, queuedProcess{ctx: ctx, process: p, stop: stop})
+	s.dispatch()
+}
+
+// Wait blocks until every submitted process has finished
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// Progress returns the completed, failed and total process counts
+func (s *Scheduler) Progress() (int, int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.completed, s.failed, s.total()
+}
+
+// RunGroups runs the site groups one after another, finishing every process
+// of a site before the next site is queued. No new site is started once ctx is done
+func (s *Scheduler) RunGroups(ctx context.Context, groups []SiteGroup) {
+	// Announce the size of the sweep so progress shows the full total.
+	s.mu.Lock()
+	for _, group := range groups {
+		s.expected += len(group.Processes)
+	}
+	s.mu.Unlock()
+
+	for _, group := range groups {
+		if ctx.Err() != nil {
+			return
+		}
+		fmt.Printf("Processing %s...\n", group.Name)
+
+		for _, process := range group.Processes {
+			s.Submit(ctx, process)
+		}
+
+		s.Wait()
+		fmt.Printf("%s completed!\n", group.Name)
+	}
+}
+
+// dispatch starts queued processes in FIFO order while slots are free.
+// A process whose browser is at its limit is skipped so it does not hold
+// back other browsers. Caller must hold s.mu.
+func (s *Scheduler) dispatch() {
+	for i := 0; i < len(s.queue); {
+		entry := s.queue[i]
+		p := entry.process
+
+		// Drop processes whose context was cancelled while queued.
+		if entry.ctx.Err() != nil {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			entry.stop()
+			s.finish(p, entry.ctx.Err())
+			s.wg.Done()
+			continue
+		}
+
+		if s.options.maxWorkers > 0 && s.running >= s.options.maxWorkers {
+			i++
+			continue
+		}
+
+		browser := p.GetBrowser()
+		limit := s.options.browserLimits[browser]
+		if limit > 0 && s.runningByBrowser[browser] >= limit {
+			i++
+			continue
+		}
+
+		// Remove from queue and start.
+		s.queue = append(s.queue[:i], s.queue[i+1:]...)
+		entry.stop()
+		s.running++
+		s.runningByBrowser[browser]++
+
+		go s.run(entry.ctx, p)
+	}
+}
+
+// run executes the process, updates the progress and frees its slot
+func (s *Scheduler) run(ctx context.Context, p *Process) {
+	defer s.wg.Done()
+
+	err := s.runProcess(ctx, p)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.running--
+	s.runningByBrowser[p.GetBrowser()]--
+	s.finish(p, err)
+	s.dispatch()
+}
+
+// finish counts a process as completed and prints the progress.
+// Caller must hold s.mu.
+func (s *Scheduler) finish(p *Process, err error) {
+	s.completed++
+	if err != nil {
+		s.failed++
+	}
+
+	status := "done"
+	if err != nil {
+		status = fmt.Sprintf("failed: %v", err)
+	}
+	fmt.Printf("[%d/%d] %s %s %dms %s\n", s.completed, s.total(),
+		p.GetBrowser(), p.GetURL(), p.GetDuration(), status)
+}
+
+// total returns the number of processes known to the scheduler.
+// Caller must hold s.mu.
+func (s *Scheduler) total() int {
+	if s.expected > s.submitted {
+		return s.expected
+	}
+	return s.submitted
+}
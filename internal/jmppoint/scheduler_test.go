@@ -0,0 +1,156 @@
+package jmppoint
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Function: Blocking Scheduler
+// Operation: Creates a scheduler whose processes run until release is closed,
+// failing those for the failing browser. Started lists the processes started, in order.
+// Return: *Scheduler, release channel, started func
+func blockingScheduler(failing string, opts ...SchedulerOptionsFunc) (*Scheduler, chan struct{}, func() []string) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var started []string
+
+	scheduler := NewScheduler(opts...)
+	scheduler.runProcess = func(ctx context.Context, p *Process) error {
+		mu.Lock()
+		started = append(started, p.GetBrowser())
+		mu.Unlock()
+
+		<-release
+		if p.GetBrowser() == failing {
+			return errors.New("crawl failed")
+		}
+		return nil
+	}
+
+	return scheduler, release, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), started...)
+	}
+}
+
+func TestSchedulerLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []SchedulerOptionsFunc
+		browsers   []string // Submitted in order.
+		running    int
+		byBrowser  map[string]int
+		queuedLeft int
+	}{
+		{
+			name:      "unlimited",
+			browsers:  []string{chrome, firefox, chrome, webkit},
+			running:   4,
+			byBrowser: map[string]int{chrome: 2, firefox: 1, webkit: 1},
+		},
+		{
+			name:       "global limit",
+			opts:       []SchedulerOptionsFunc{WithMaxWorkers(2)},
+			browsers:   []string{chrome, firefox, chrome, webkit},
+			running:    2,
+			byBrowser:  map[string]int{chrome: 1, firefox: 1},
+			queuedLeft: 2,
+		},
+		{
+			name:       "browser limit does not hold back other browsers",
+			opts:       []SchedulerOptionsFunc{WithBrowserLimit(firefox, 1)},
+			browsers:   []string{firefox, firefox, firefox, chrome, chrome},
+			running:    3,
+			byBrowser:  map[string]int{firefox: 1, chrome: 2},
+			queuedLeft: 2,
+		},
+		{
+			name:       "browser and global limits",
+			opts:       []SchedulerOptionsFunc{WithMaxWorkers(3), WithBrowserLimit(chrome, 2), WithBrowserLimit(webkit, 1)},
+			browsers:   []string{chrome, chrome, chrome, webkit, webkit, firefox},
+			running:    3,
+			byBrowser:  map[string]int{chrome: 2, webkit: 1},
+			queuedLeft: 3,
+		},
+		{
+			name:       "limit of 0 is unlimited",
+			opts:       []SchedulerOptionsFunc{WithBrowserLimit(chrome, 0), WithBrowserLimit(firefox, 1)},
+			browsers:   []string{chrome, chrome, chrome, firefox, firefox},
+			running:    4,
+			byBrowser:  map[string]int{chrome: 3, firefox: 1},
+			queuedLeft: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheduler, release, _ := blockingScheduler("", test.opts...)
+			for _, browser := range test.browsers {
+				scheduler.Submit(context.Background(), NewProcess(WithBrowser(browser)))
+			}
+
+			scheduler.mu.Lock()
+			running, queued := scheduler.running, len(scheduler.queue)
+			byBrowser := make(map[string]int)
+			for browser, count := range scheduler.runningByBrowser {
+				if count > 0 {
+					byBrowser[browser] = count
+				}
+			}
+			scheduler.mu.Unlock()
+
+			if running != test.running || queued != test.queuedLeft {
+				t.Errorf("running %d queued %d, want %d and %d", running, queued, test.running, test.queuedLeft)
+			}
+			if !reflect.DeepEqual(byBrowser, test.byBrowser) {
+				t.Errorf("running by browser %v, want %v", byBrowser, test.byBrowser)
+			}
+
+			// Queued processes start as slots free up, until all have run.
+			close(release)
+			scheduler.Wait()
+			completed, failed, total := scheduler.Progress()
+			if completed != len(test.browsers) || failed != 0 || total != len(test.browsers) {
+				t.Errorf("progress %d/%d with %d failed, want %d/%d", completed, total, failed,
+					len(test.browsers), len(test.browsers))
+			}
+		})
+	}
+}
+
+func TestSchedulerCancelQueued(t *testing.T) {
+	scheduler, release, started := blockingScheduler(firefox, WithMaxWorkers(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Submit(context.Background(), NewProcess(WithBrowser(firefox)))
+	scheduler.Submit(ctx, NewProcess(WithBrowser(chrome)))
+	scheduler.Submit(ctx, NewProcess(WithBrowser(webkit)))
+
+	// Cancelling drops the queued processes without waiting for a slot.
+	cancel()
+	deadline := time.Now().Add(2 * time.Second)
+	completed, failed, _ := scheduler.Progress()
+	for completed < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		completed, failed, _ = scheduler.Progress()
+	}
+	if completed != 2 || failed != 2 {
+		t.Errorf("after cancel %d completed %d failed, want 2 and 2", completed, failed)
+	}
+
+	close(release)
+	scheduler.Wait()
+
+	completed, failed, total := scheduler.Progress()
+	if completed != 3 || failed != 3 || total != 3 {
+		t.Errorf("progress %d/%d with %d failed, want 3/3 with 3 failed", completed, total, failed)
+	}
+	if got := started(); !reflect.DeepEqual(got, []string{firefox}) {
+		t.Errorf("started %v, want only %s", got, firefox)
+	}
+}
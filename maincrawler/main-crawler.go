@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"net"
+	"os"
+	"os/signal"
 	"privcrawler/internal/crawler"
 )
 
@@ -14,35 +18,90 @@ func main() {
 	isHidden := flag.Bool("i", false, "Hides browser")
 	url := flag.String("u", "https://www.amazon.com", "URL for website to analyze")
 	duration := flag.Int("d", 20000, "Duration for the browser to run in milliseconds (default: 20000)")
+	depth := flag.Int("l", 0, "Depth of same-site links to follow (default: 0, landing page only)")
+	maxPages := flag.Int("p", 0, "Maximum pages to visit when following links (default: 10)")
+	waitMode := flag.String("w", "fixed", "Wait strategy: fixed, networkidle, domready or cookie-stable")
+	grace := flag.Int("g", 0, "Grace period after DOM ready in milliseconds (domready)")
+	stableFor := flag.Int("s", 0, "Milliseconds the cookie set must stay unchanged (cookie-stable, default: 3000)")
+	maxWait := flag.Int("m", 0, "Hard maximum wait in milliseconds (default: 30000)")
+	consent := flag.String("k", "no-action", "Consent banner mode: no-action, accept-all, reject-all or compare")
+	snapshot := flag.Int("c", 0, "Milliseconds between cookie snapshots for the timeline (default: 0, once per page)")
+	gpc := flag.Bool("t", false, "Compare crawls without and with GPC/DNT privacy signals (report in GPC.txt)")
+	cname := flag.String("n", "", "Resolve CNAMEs to find cloaked trackers: 'system' or a DNS server host:port (default: off)")
 
 
 	// Parse command line flags
 	flag.Parse()
 
-	browserList := crawler.GetBrowsers(verbose)
-	crawler.VerifyTargetBrowser(browserList, *browser, verbose)
+	// Ctrl-C stops the crawl and keeps the cookies collected so far.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
 	// --- TESTING COOKIES WITH MULTIPLE URL's AND TESTING SAFE AND LESS SAFE URL's ---
 
-	// Declare structure for privacy metrics
-	safePrivacyMetric := crawler.PrivacyMetric{}
+	// Wait strategy for each page
+	wait := crawler.WaitStrategy{
+		Mode:      crawler.WaitMode(*waitMode),
+		Grace:     *grace,
+		StableFor: *stableFor,
+		MaxWait:   *maxWait,
+	}
+	if err := wait.Validate(); err != nil {
+		fmt.Printf("Invalid wait strategy: %v\n", err)
+		return
+	}
 
-	// Fetch cookies from amazon
-	cookie1 := crawler.FetchCookies(*browser, *isHidden, *url, &safePrivacyMetric, verbose, *duration)
+	consentMode := crawler.ConsentMode(*consent)
+	if err := crawler.ValidateConsentMode(consentMode); err != nil {
+		fmt.Printf("Invalid consent mode: %v\n", err)
+		return
+	}
 
-	// Print cookies from amazon
-	crawler.PrintCookies(cookie1, *url, verbose)
+	// Retry policy for navigation failures
+	retry, err := crawler.ReadRetryPolicy(crawler.RETRYFILE)
+	if err != nil {
+		fmt.Printf("%v, using default retry policy\n", err)
+		retry = crawler.DefaultRetryPolicy()
+	}
 
-	urltarget := *url + ": Cookies"
-	// Print metrics from amazon
-	data := crawler.GetMetricsReport(safePrivacyMetric, urltarget)
+	options := crawler.CrawlOptions{
+		Browser:  *browser,
+		Hidden:   *isHidden,
+		URL:      *url,
+		Duration: *duration,
+		Verbose:  *verbose,
+		Depth:    *depth,
+		MaxPages: *maxPages,
+		Retry:    &retry,
+		Wait:     wait,
+		Consent:  consentMode,
 
+		SnapshotInterval: *snapshot,
+		GPCCompare:       *gpc,
+		PrintReports:     true,
+	}
 
-	err := crawler.AppendDataToFile(data, *url, *browser)
-	if err != nil {
-		fmt.Printf("Error appending report to file: %v\n", err)
+	// CNAME cloaking lookups, off unless a resolver is given
+	switch *cname {
+	case "":
+	case "system":
+		options.CNAME = crawler.NewCNAMEResolver("")
+	default:
+		if _, _, err := net.SplitHostPort(*cname); err != nil {
+			fmt.Printf("Invalid CNAME resolver: %v\n", err)
+			return
+		}
+		options.CNAME = crawler.NewCNAMEResolver(*cname)
 	}
 
-	// Print out the report gathered from amazon
-	fmt.Println(data)
+	// Crawl, audit and append the reports to their files, printing them as well
+	cookies, err := crawler.RunPrivacyCrawlWithOptions(ctx, options)
+
+	// Print the cookies of the baseline crawl, even a partial or failed one
+	if cookies != nil {
+		crawler.PrintCookies(cookies, *url, verbose)
+	}
+	if err != nil {
+		fmt.Printf("Error running privacy crawl: %v\n", err)
+	}
 }